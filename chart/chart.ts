@@ -32,9 +32,38 @@ interface Point {
   y: number;
 }
 interface LineData {
-  type: "horizontalStraightLine" | "verticalStraightLine" | "segment";
+  type: "horizontalStraightLine" | "verticalStraightLine" | "segment" | "simpleAnnotation";
   config: LineConfig;
   points: Point[];
+  text?: string;
+}
+interface EquityPoint {
+  index: number;
+  timestamp: number;
+  equity: number | null;
+  drawdown: number | null;
+}
+interface Report {
+  netProfit: number;
+  maxDrawdownPercent: number;
+  equityCurve: EquityPoint[];
+}
+interface Update {
+  bars: Bar[];
+  index: number;
+  plots: PlotsData;
+  lines: LineData[] | null;
+}
+interface Input {
+  name: string;
+  kind: "int" | "float" | "bool" | "enum";
+  value: number | boolean | string;
+  default: number | boolean | string;
+  min: number;
+  max: number;
+  step: number;
+  options?: string[];
+  description?: string;
 }
 interface PlotConfig {
   color?: string;
@@ -95,15 +124,43 @@ export async function Init(element: string | HTMLElement) {
   const chart = init(element, { timezone: "UTC" })!;
   console.log("Inited");
 
-  const [response1, response2, response3] = await Promise.all([
-    fetch("http://localhost:3000/bars"),
-    fetch("http://localhost:3000/plots"),
-    fetch("http://localhost:3000/lines"),
+  // subscribe first so no update is lost while loading, updates are idempotent
+  const pending: Update[] = [];
+  let loaded = false;
+  let connected = false;
+  let plots: PlotsData = {};
+
+  // API URLs are relative to the page, so the chart works wherever its handler is mounted
+  const source = new EventSource("stream");
+  source.onopen = () => {
+    // missed updates while disconnected, start over
+    if (connected) {
+      window.location.reload();
+    }
+    connected = true;
+  };
+  source.onmessage = (event) => {
+    const update = JSON.parse(event.data) as Update;
+    if (loaded) {
+      applyUpdate(chart, plots, update);
+    } else {
+      pending.push(update);
+    }
+  };
+
+  const [response1, response2, response3, response4, response5] = await Promise.all([
+    fetch("bars"),
+    fetch("plots"),
+    fetch("lines"),
+    fetch("report"),
+    fetch("inputs"),
   ]);
 
   const bars = (await response1.json()) as Bar[];
-  const plots = (await response2.json()) as PlotsData;
+  plots = (await response2.json()) as PlotsData;
   const lines = (await response3.json()) as LineData[];
+  const report = (await response4.json()) as Report | null;
+  const inputs = (await response5.json()) as Input[];
 
   chart.applyNewData(sortBars(bars) as klinecharts.KLineData[]);
 
@@ -112,9 +169,98 @@ export async function Init(element: string | HTMLElement) {
   if (lines && lines.length > 0) {
     applyLines(chart, lines);
   }
+
+  if (report && report.equityCurve.length > 0) {
+    applyEquity(chart, report);
+  }
+
+  if (inputs.length > 0) {
+    applyInputs(inputs);
+  }
+
+  loaded = true;
+  pending.forEach((update) => applyUpdate(chart, plots, update));
   return chart;
 }
 
+// applyUpdate merges a /stream delta into the chart
+function applyUpdate(chart: klinecharts.Chart, plots: PlotsData, update: Update) {
+  const rebuild = new Set<string>();
+
+  Object.entries(update.plots || {}).forEach(([label, plot]) => {
+    const existing = plots[label];
+    if (!existing) {
+      plots[label] = plot;
+      rebuild.add(plot.config.location || "pane_1");
+      return;
+    }
+
+    plot.data.forEach((point) => {
+      existing.data[point.index] = point;
+    });
+  });
+
+  // a new plot changes the figures of its pane, recreate the pane
+  if (rebuild.size > 0) {
+    const rebuilt: PlotsData = {};
+    Object.entries(plots).forEach(([label, plot]) => {
+      const location = plot.config.location || "pane_1";
+      if (rebuild.has(location)) {
+        rebuilt[label] = plot;
+      }
+    });
+
+    rebuild.forEach((location) => chart.removeIndicator(location, location));
+    applyIndicators(chart, rebuilt);
+  }
+
+  if (update.lines && update.lines.length > 0) {
+    applyLines(chart, update.lines);
+  }
+
+  if (update.bars && update.bars.length > 0) {
+    sortBars(update.bars).forEach((bar) => chart.updateData(bar as klinecharts.KLineData));
+    refreshEquity(chart);
+  }
+}
+
+// refreshEquity fetches the report again once new bars moved the equity, one refresh at a time
+let refreshing = Promise.resolve();
+function refreshEquity(chart: klinecharts.Chart) {
+  refreshing = refreshing
+    .then(async () => {
+      const report = (await (await fetch("report")).json()) as Report | null;
+      if (report && report.equityCurve.length > 0) {
+        chart.removeIndicator("equity", "equity");
+        applyEquity(chart, report);
+      }
+    })
+    .catch((error) => console.log(error));
+}
+
+// the same color when the equity is refreshed
+let equityColor: string | undefined;
+function applyEquity(chart: klinecharts.Chart, report: Report) {
+  equityColor = equityColor || getColor();
+  registerIndicator({
+    name: "equity",
+    shortName: `Equity (net ${report.netProfit.toFixed(2)}, max dd ${report.maxDrawdownPercent.toFixed(2)}%)`,
+    calcParams: [],
+    precision: 2,
+    figures: [{ key: "equity", type: "line", title: "equity:" }],
+    styles: {
+      lines: [{ size: 1, color: equityColor, smooth: 0, style: LineType.Solid, dashedValue: [] }],
+    },
+    calc: (kLineDataList) =>
+      kLineDataList.map((_, i) => {
+        const point = report.equityCurve[i];
+        return { equity: !point || point.equity === null ? NaN : point.equity };
+      }),
+  });
+
+  chart.createIndicator("equity", false, { id: "equity", height: 100, minHeight: 30, dragEnabled: true });
+}
+
 function applyIndicators(chart: klinecharts.Chart, plots: PlotsData) {
   const organizedPlots = Object.values(plots).reduce((acc, plot) => {
     const location = plot.config.location || "pane_1"; // default to oscillator if no location is specified
@@ -154,8 +300,8 @@ function applyIndicators(chart: klinecharts.Chart, plots: PlotsData) {
           const data: { [key: string]: any } = {};
 
           for (let j = 0; j < plots.length; j++) {
-            const value = plots[j].data[i].value;
-            data[`line_${j + 1}`] = value === null ? NaN : value;
+            const point = plots[j].data[i];
+            data[`line_${j + 1}`] = !point || point.value === null ? NaN : point.value;
           }
 
           return data;
@@ -189,6 +335,7 @@ function applyLines(chart: klinecharts.Chart, lines: LineData[]) {
       {
         name: line.type,
         points: line.points.map((l) => ({ timestamp: l.x * 1000, value: l.y })),
+        extendData: line.text,
         styles: {
           line: {
             color: line.config.color || getColor(),
@@ -202,3 +349,73 @@ function applyLines(chart: klinecharts.Chart, lines: LineData[]) {
     );
   }
 }
+
+// applyInputs shows a settings panel, applying it runs the logic again on the server and reloads the chart
+function applyInputs(inputs: Input[]) {
+  const panel = document.createElement("form");
+  panel.style.cssText =
+    "position:fixed;top:8px;right:8px;z-index:10;padding:8px;background:#fff;border:1px solid #ddd;font:12px sans-serif";
+
+  const fields = inputs.map((input) => {
+    const row = document.createElement("label");
+    row.style.cssText = "display:flex;justify-content:space-between;gap:8px;margin-bottom:4px";
+    row.title = input.description || "";
+    row.append(input.name);
+
+    let field: HTMLInputElement | HTMLSelectElement;
+    if (input.kind === "enum") {
+      const select = document.createElement("select");
+      (input.options || []).forEach((option) => select.add(new Option(option, option)));
+      select.value = String(input.value);
+      field = select;
+    } else {
+      field = document.createElement("input");
+      if (input.kind === "bool") {
+        field.type = "checkbox";
+        field.checked = input.value as boolean;
+      } else {
+        field.type = "number";
+        field.style.width = "80px";
+        field.step = input.step ? String(input.step) : "any";
+        if (input.max > input.min) {
+          field.min = String(input.min);
+          field.max = String(input.max);
+        }
+        field.value = String(input.value);
+      }
+    }
+
+    row.append(field);
+    panel.append(row);
+    return { input, field };
+  });
+
+  const apply = document.createElement("button");
+  apply.textContent = "Apply";
+  panel.append(apply);
+
+  panel.onsubmit = async (event) => {
+    event.preventDefault();
+
+    const values: { [name: string]: number | boolean | string } = {};
+    fields.forEach(({ input, field }) => {
+      if (input.kind === "bool") {
+        values[input.name] = (field as HTMLInputElement).checked;
+      } else if (input.kind === "enum") {
+        values[input.name] = field.value;
+      } else {
+        values[input.name] = Number(field.value);
+      }
+    });
+
+    const response = await fetch("inputs", { method: "POST", body: JSON.stringify(values) });
+    if (!response.ok) {
+      alert(await response.text());
+      return;
+    }
+
+    window.location.reload();
+  };
+
+  document.body.append(panel);
+}
@@ -3,6 +3,13 @@ import { resolve } from 'path';
 
 export default defineConfig({
   root: './',
+  base: './', // relative asset URLs, the chart can be served under any prefix
+  server: {
+    // the API is served by GoQuant, e.g. GQ.Server(3000)
+    proxy: Object.fromEntries(
+      ['/bars', '/plots', '/lines', '/report', '/inputs', '/stream'].map((path) => [path, 'http://localhost:3000']),
+    ),
+  },
   build: {
     outDir: './dist',
     rollupOptions: {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	gq "github.com/Go-Quant/goquant/core"
 	"github.com/Go-Quant/goquant/serie"
@@ -17,8 +18,15 @@ func main() {
 	}
 
 	GQ = gq.New()
+	// e.g. go run ./cmd -length=20
+	if err := GQ.ParseInputArgs(os.Args[1:]); err != nil {
+		panic(err)
+	}
 	GQ.AddBars(data)
 	GQ.Logic(myLogic)
+	if err := GQ.InputError(); err != nil {
+		panic(err)
+	}
 
 	fmt.Printf("Server running at http://localhost:%d\n", port)
 	panic(GQ.Server(port))
@@ -31,8 +39,10 @@ type Point = gq.Point
 
 func myLogic(open, high, close, low, volume, _time serie.Serie, ta gq.TA, plot gq.PlotF, line gq.LineF, vline gq.VLineF, hline gq.HLineF) {
 
-	rsi := ta.RSI(close, 14).Get() // or .G(0)
-	sma := ta.SMA(close, 14).Get() // or .G(0)
+	length := float64(GQ.InputInt("length", 14, &gq.InputConfig{Min: 2, Max: 50, Description: "RSI and SMA length"}))
+
+	rsi := ta.RSI(close, length).Get() // or .G(0)
+	sma := ta.SMA(close, length).Get() // or .G(0)
 
 	plot(sma, &PlotConfig{Location: "candle_pane"})
 	plot(rsi, nil, "rsi")
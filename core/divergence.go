@@ -0,0 +1,152 @@
+package core
+
+import (
+	"math"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+type DivergenceKind string
+
+const (
+	BullishDivergence       DivergenceKind = "bullish"
+	BearishDivergence       DivergenceKind = "bearish"
+	HiddenBullishDivergence DivergenceKind = "hiddenBullish"
+	HiddenBearishDivergence DivergenceKind = "hiddenBearish"
+)
+
+// bits of the kinds that fired on a bar
+var divergenceBits = []struct {
+	kind DivergenceKind
+	bit  int
+}{
+	{BullishDivergence, 1},
+	{BearishDivergence, 2},
+	{HiddenBullishDivergence, 4},
+	{HiddenBearishDivergence, 8},
+}
+
+// Divergences are flagged on the bar a pivot of the oscillator is confirmed, lbR bars after it, comparing it
+// to the previous pivot of the same side. Draw them from (PrevLowIndex, LowIndex) or (PrevHighIndex, HighIndex)
+type Divergences struct {
+	Bullish       serie.Serie // True or False: lower low of the price, higher low of the oscillator
+	Bearish       serie.Serie // higher high of the price, lower high of the oscillator
+	HiddenBullish serie.Serie // higher low of the price, lower low of the oscillator
+	HiddenBearish serie.Serie // lower high of the price, higher high of the oscillator
+
+	// bar indices of the last two pivots of the oscillator, NaN before them
+	PrevLowIndex  serie.Serie
+	LowIndex      serie.Serie
+	PrevHighIndex serie.Serie
+	HighIndex     serie.Serie
+
+	kinds serie.Serie
+}
+
+// Kinds returns the divergences that fired on the current bar
+func (d Divergences) Kinds() []DivergenceKind {
+	bits := int(d.kinds.Get())
+
+	var kinds []DivergenceKind
+	for _, b := range divergenceBits {
+		if bits&b.bit != 0 {
+			kinds = append(kinds, b.kind)
+		}
+	}
+	return kinds
+}
+
+// divergence compares the pivots of osc (RSI, MACD, CCI, OBV...) to the low and the high of the bars they're on.
+// Like Pine's divergence indicator, a pivot has lbL bars on its left and lbR bars on its right, and is compared to the
+// previous one only if rangeLower <= bars between their confirmations - 1 <= rangeUpper
+func (g *GoQuant) divergence(osc serie.Serie, lbR, lbL, rangeUpper, rangeLower int, label ...string) Divergences {
+	lbl := g.labelOf("divergence", label)
+
+	// bar index + 1 of the pivots, 0 before the first ones
+	lows := g.NewEmptyStorage(lbl + "low")
+	prevLows := g.NewEmptyStorage(lbl + "prevLow")
+	highs := g.NewEmptyStorage(lbl + "high")
+	prevHighs := g.NewEmptyStorage(lbl + "prevHigh")
+
+	pivotLow := g.pivotLow(lbL, lbR, osc)
+	pivotHigh := g.pivotHigh(lbL, lbR, osc)
+
+	// compare returns the divergence between the pivot lbR bars back and the one at prev (bar index + 1):
+	// lowerPrice when the price made a lower pivot and osc a higher one, higherPrice the other way round
+	compare := func(prev float64, price serie.Serie, lowerPrice, higherPrice int) int {
+		pivot := g.BarIndex() - g.BarFuncIndex() - lbR
+		if prev < 1 {
+			return 0
+		}
+
+		if bars := pivot - int(prev-1) - 1; bars < rangeLower || bars > rangeUpper {
+			return 0
+		}
+
+		back := lbR + pivot - int(prev-1)
+		switch {
+		case price.G(lbR) < price.G(back) && osc.G(lbR) > osc.G(back):
+			return lowerPrice
+		case price.G(lbR) > price.G(back) && osc.G(lbR) < osc.G(back):
+			return higherPrice
+		}
+		return 0
+	}
+
+	kinds := g.NewWrapper(func() float64 {
+		index := g.BarIndex() - g.BarFuncIndex()
+		low, prevLow := serie.NZ(lows.G(1)), serie.NZ(prevLows.G(1))
+		high, prevHigh := serie.NZ(highs.G(1)), serie.NZ(prevHighs.G(1))
+		bits := 0
+
+		// pivotLow and pivotHigh are True on NaN values and before the first bars
+		isPivot := index-lbR-lbL >= 0 && !serie.NA(osc.G(lbR))
+
+		if isPivot && pivotLow.Get() == True {
+			bits |= compare(low, g.low, 1, 4) // bullish, hidden bullish
+			prevLow, low = low, float64(index-lbR+1)
+		}
+
+		if isPivot && pivotHigh.Get() == True {
+			bits |= compare(high, g.high, 8, 2) // hidden bearish, bearish
+			prevHigh, high = high, float64(index-lbR+1)
+		}
+
+		*lows.Set(0) = low
+		*prevLows.Set(0) = prevLow
+		*highs.Set(0) = high
+		*prevHighs.Set(0) = prevHigh
+		return float64(bits)
+	}).Cache(BUILT_IN + lbl)
+
+	flag := func(bit int) serie.Serie {
+		return g.NewWrapper(func() float64 {
+			if int(kinds.Get())&bit != 0 {
+				return True
+			}
+			return False
+		})
+	}
+
+	indexOf := func(pivots serie.Serie) serie.Serie {
+		return g.NewWrapper(func() float64 {
+			kinds.Get()
+			if pivot := pivots.Get(); pivot >= 1 {
+				return pivot - 1
+			}
+			return math.NaN()
+		})
+	}
+
+	return Divergences{
+		Bullish:       flag(1),
+		Bearish:       flag(2),
+		HiddenBullish: flag(4),
+		HiddenBearish: flag(8),
+		PrevLowIndex:  indexOf(prevLows),
+		LowIndex:      indexOf(lows),
+		PrevHighIndex: indexOf(prevHighs),
+		HighIndex:     indexOf(highs),
+		kinds:         kinds,
+	}
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"math"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// rollingExtreme is a monotonic deque of the bars that can still become the extreme of the window, oldest first.
+// Each bar is pushed and popped once, so the extreme costs O(1) amortized per bar
+type rollingExtreme struct {
+	last    int // bar index of the last pushed bar, -1 if none
+	indexes []int
+	values  []float64
+}
+
+// push adds the value of the bar at index and drops the bars out of the window,
+// better reports whether a beats b, ties go to the most recent bar
+func (d *rollingExtreme) push(index int, value float64, length int, better func(a, b float64) bool) {
+	d.last = index
+
+	if !serie.NA(value) {
+		for len(d.values) > 0 && !better(d.values[len(d.values)-1], value) {
+			d.values = d.values[:len(d.values)-1]
+			d.indexes = d.indexes[:len(d.indexes)-1]
+		}
+		d.values = append(d.values, value)
+		d.indexes = append(d.indexes, index)
+	}
+
+	for len(d.indexes) > 0 && d.indexes[0] <= index-length {
+		d.values = d.values[1:]
+		d.indexes = d.indexes[1:]
+	}
+}
+
+// extreme returns the extreme of src over the last length bars and how many bars back it is, skipping NaN values.
+// Bars are read sequentially through the deque; the results are kept in storages, so reading them back with B(n) is O(1)
+func (g *GoQuant) extreme(src serie.Serie, length int, lbl string, better func(a, b float64) bool) (float64, int) {
+	values := g.NewEmptyStorage(lbl)
+	offsets := g.NewEmptyStorage(lbl + "bars") // offset + 1, 0 if not computed
+
+	if offset := offsets.Get(); offset >= 1 {
+		return values.Get(), int(offset) - 1
+	}
+
+	index := g.BarIndex() - g.BarFuncIndex()
+	d := g.extremes[lbl]
+	if d == nil {
+		d = &rollingExtreme{last: -1}
+		g.extremes[lbl] = d
+	}
+
+	var value float64
+	var offset int
+
+	switch {
+	case d.last >= 0 && index == d.last+1:
+		d.push(index, src.Get(), length, better)
+	case index > d.last:
+		// first bar, or bars were skipped
+		d.indexes, d.values = d.indexes[:0], d.values[:0]
+		for i := length - 1; i >= 0; i-- {
+			d.push(index-i, src.G(i), length, better)
+		}
+	default:
+		// an older bar that wasn't computed, scan its window
+		value, offset = math.NaN(), 0
+		for i := 0; i < length; i++ {
+			if v := src.G(i); !serie.NA(v) && (serie.NA(value) || better(v, value)) {
+				value, offset = v, i
+			}
+		}
+		return value, offset
+	}
+
+	if len(d.values) == 0 {
+		return math.NaN(), 0
+	}
+
+	value, offset = d.values[0], index-d.indexes[0]
+	*values.Set(0) = value
+	*offsets.Set(0) = float64(offset + 1)
+	return value, offset
+}
+
+func higher(a, b float64) bool { return a > b }
+func lower(a, b float64) bool  { return a < b }
+
+// highest returns the highest value of src over the last length bars, skipping NaN values
+func (g *GoQuant) highest(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("highest", label, length)
+
+	return g.NewWrapper(func() float64 {
+		value, _ := g.extreme(src, int(length), lbl, higher)
+		return value
+	})
+}
+
+// lowest returns the lowest value of src over the last length bars, skipping NaN values
+func (g *GoQuant) lowest(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("lowest", label, length)
+
+	return g.NewWrapper(func() float64 {
+		value, _ := g.extreme(src, int(length), lbl, lower)
+		return value
+	})
+}
+
+// highestBars returns the offset to the highest value of src over the last length bars, 0 or negative like Pine.
+// It keeps its own deque, so it doesn't mix up sources with highest under the same label
+func (g *GoQuant) highestBars(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("highestbars", label, length)
+
+	return g.NewWrapper(func() float64 {
+		value, offset := g.extreme(src, int(length), lbl, higher)
+		if serie.NA(value) {
+			return math.NaN()
+		}
+		return -float64(offset)
+	})
+}
+
+// lowestBars returns the offset to the lowest value of src over the last length bars, 0 or negative like Pine
+func (g *GoQuant) lowestBars(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("lowestbars", label, length)
+
+	return g.NewWrapper(func() float64 {
+		value, offset := g.extreme(src, int(length), lbl, lower)
+		if serie.NA(value) {
+			return math.NaN()
+		}
+		return -float64(offset)
+	})
+}
@@ -2,24 +2,46 @@ package core
 
 import (
 	_ "embed"
-	"encoding/json"
 	"fmt"
-	"io/fs"
+	"log"
 	"math"
-	"net/http"
-	"runtime"
+	"sync"
 
-	assets "github.com/Go-Quant/goquant"
 	"github.com/Go-Quant/goquant/serie"
+	"github.com/Go-Quant/goquant/strategy"
 )
 
 type GoQuant struct {
+	mu            sync.RWMutex // guards the state read by the server while Logic runs
 	loopIndex     int
 	loopFuncIndex int
 	bars          []serie.Bar
 	taStorage     map[string]serie.Serie
 	plotStorage   map[string]PlotData
 	lineStorage   []LineData
+	cache         map[string]map[int]float64 // label -> bar index -> value, filled by serie.Serie.Cache
+	extremes      map[string]*rollingExtreme // label -> deque of highest, lowest...
+	strategy      *strategy.Strategy
+	report        *strategy.Report
+	securities    map[string]*Security
+	loaded        []*Security // by SecurityFromBars
+	stream        *stream
+	identityMode  IdentityMode
+	identities    map[string]string // indicator key -> params, checked unless IdentityOff
+	frames        []frame           // calls counted by CallSite, the logic and the wrappers being evaluated
+	sites         map[uintptr]site  // program counter -> call site, see CallSite
+	logger        *log.Logger       // of the identity warnings, log's standard logger when nil
+	logic         LogicF            // last run by Logic, run again when the inputs are set from the chart
+
+	inputs         map[string]*Input
+	inputOrder     []string
+	inputOverrides map[string]any // values set before the inputs are declared
+	inputErr       error          // of the overrides found invalid by Logic since the last Reset
+
+	transform serie.BarTransform // builds bars from realBars when set
+	realBars  []serie.Bar
+	sources   []int // index of the real bar each bar was completed on
+	realIndex int   // next real bar the strategy fills orders against
 
 	open   serie.Serie
 	high   serie.Serie
@@ -32,11 +54,78 @@ type GoQuant struct {
 type TA struct {
 	EMA        func(src serie.Serie, length float64, label ...string) serie.Serie
 	ATR        func(length float64, label ...string) serie.Serie
-	SMA        func(src serie.Serie, length float64) serie.Serie
-	VWMA       func(src serie.Serie, length float64) serie.Serie
+	SMA        func(src serie.Serie, length float64, label ...string) serie.Serie
+	VWMA       func(src serie.Serie, length float64, label ...string) serie.Serie
 	RMA        func(src serie.Serie, length float64, label ...string) serie.Serie
 	RSI        func(src serie.Serie, length float64, label ...string) serie.Serie
-	Divergence func(rsiLen int, rsiSource serie.Serie, lbR, lbL, rangeUpper, rangeLower int, label ...string) serie.Serie
+	Divergence func(osc serie.Serie, lbR, lbL, rangeUpper, rangeLower int, label ...string) Divergences
+
+	Sum                   func(src serie.Serie, length float64, label ...string) serie.Serie
+	Stdev                 func(src serie.Serie, length float64, label ...string) serie.Serie
+	Variance              func(src serie.Serie, length float64, label ...string) serie.Serie
+	Covariance            func(src1, src2 serie.Serie, length float64, label ...string) serie.Serie
+	Correlation           func(src1, src2 serie.Serie, length float64, label ...string) serie.Serie
+	Beta                  func(src, benchmark serie.Serie, length float64, label ...string) serie.Serie
+	ZScore                func(src serie.Serie, length float64, label ...string) serie.Serie
+	LinReg                func(src serie.Serie, length float64, offset int) serie.Serie
+	LinRegSlope           func(src serie.Serie, length float64) serie.Serie
+	LinRegIntercept       func(src serie.Serie, length float64) serie.Serie
+	PercentRank           func(src serie.Serie, length float64) serie.Serie
+	PercentileNearestRank func(src serie.Serie, length, percentage float64) serie.Serie
+	PercentileLinear      func(src serie.Serie, length, percentage float64) serie.Serie
+	Median                func(src serie.Serie, length float64) serie.Serie
+
+	Highest     func(src serie.Serie, length float64, label ...string) serie.Serie
+	Lowest      func(src serie.Serie, length float64, label ...string) serie.Serie
+	HighestBars func(src serie.Serie, length float64, label ...string) serie.Serie
+	LowestBars  func(src serie.Serie, length float64, label ...string) serie.Serie
+
+	WMA  func(src serie.Serie, length float64) serie.Serie
+	HMA  func(src serie.Serie, length float64) serie.Serie
+	DEMA func(src serie.Serie, length float64, label ...string) serie.Serie
+	TEMA func(src serie.Serie, length float64, label ...string) serie.Serie
+	ALMA func(src serie.Serie, length, offset, sigma float64) serie.Serie
+	KAMA func(src serie.Serie, length, fastLength, slowLength float64, label ...string) serie.Serie
+	LSMA func(src serie.Serie, length float64) serie.Serie
+	T3   func(src serie.Serie, length, vfactor float64, label ...string) serie.Serie
+	MA   func(kind string, src serie.Serie, length float64, label ...string) serie.Serie
+
+	MACD     func(src serie.Serie, fastLength, slowLength, signalLength float64, label ...string) MACDLines
+	BB       func(src serie.Serie, length, mult float64, label ...string) BollingerBands
+	Stoch    func(periodK, smoothK, periodD float64, label ...string) Stochastic
+	StochRSI func(src serie.Serie, lengthRSI, lengthStoch, smoothK, smoothD float64, label ...string) Stochastic
+
+	Keltner              func(src serie.Serie, length, mult float64, label ...string) Channel
+	Donchian             func(length float64, label ...string) Channel
+	ATRBands             func(src serie.Serie, length, mult float64, label ...string) Channel
+	Chandelier           func(length, mult float64, label ...string) ChandelierExit
+	HistoricalVolatility func(kind string, length, periodsPerYear float64, label ...string) serie.Serie
+
+	CCI                func(src serie.Serie, length float64, label ...string) serie.Serie
+	WilliamsR          func(length float64, label ...string) serie.Serie
+	ROC                func(src serie.Serie, length float64) serie.Serie
+	Momentum           func(src serie.Serie, length float64) serie.Serie
+	TRIX               func(src serie.Serie, length float64, label ...string) serie.Serie
+	UltimateOscillator func(fastLength, middleLength, slowLength float64, label ...string) serie.Serie
+	AwesomeOscillator  func(fastLength, slowLength float64, label ...string) serie.Serie
+
+	DMI        func(diLength, adxSmoothing float64, label ...string) DMILines
+	SuperTrend func(factor, atrPeriod float64, label ...string) SuperTrendLine
+	SAR        func(start, inc, max float64, label ...string) serie.Serie
+	Ichimoku   func(conversionLength, baseLength, spanBLength, displacement int, label ...string) IchimokuCloud
+
+	ZigZag          func(config *ZigZagConfig, label ...string) ZigZag
+	MarketStructure func(leftBars, rightBars int, label ...string) MarketStructure
+	PivotPoints     func(config *PivotConfig, label ...string) PivotLevels
+
+	OBV          func(label ...string) serie.Serie
+	AccDist      func(label ...string) serie.Serie
+	CMF          func(length float64, label ...string) serie.Serie
+	MFI          func(src serie.Serie, length float64, label ...string) serie.Serie
+	VWAP         func(src serie.Serie, config *VWAPConfig, label ...string) VWAPLines
+	AnchoredVWAP func(src serie.Serie, anchor func() bool, label ...string) VWAPLines
+
+	Patterns func(config *PatternConfig, label ...string) CandlePatterns
 
 	Cross      func(src1, src2 serie.Serie) serie.Serie
 	CrossOver  func(src1, src2 serie.Serie) serie.Serie
@@ -57,6 +146,7 @@ const (
 	HorizontalStraightLine LineType = "horizontalStraightLine"
 	VerticalStraightLine   LineType = "verticalStraightLine"
 	Segment                LineType = "segment"
+	Annotation             LineType = "simpleAnnotation"
 )
 
 type PlotPoint struct {
@@ -73,6 +163,7 @@ type LineData struct {
 	Type   LineType   `json:"type"`
 	Config LineConfig `json:"config"`
 	Points []Point    `json:"points"`
+	Text   string     `json:"text,omitempty"` // text of an Annotation
 }
 
 type LineConfig struct {
@@ -90,11 +181,42 @@ type Point struct {
 
 // // //
 
+// New creates an independent GoQuant instance. Storages and caches are owned by the instance,
+// so several instances (e.g. one per symbol or timeframe) can run side by side, each in its own goroutine.
+// A single instance must not be used from multiple goroutines at once.
 func New() *GoQuant {
-	return &GoQuant{taStorage: make(map[string]serie.Serie), plotStorage: make(map[string]PlotData)}
+	return &GoQuant{
+		taStorage:   make(map[string]serie.Serie),
+		plotStorage: make(map[string]PlotData),
+		cache:       make(map[string]map[int]float64),
+		extremes:    make(map[string]*rollingExtreme),
+		identities:  make(map[string]string),
+		sites:       make(map[uintptr]site),
+		securities:  make(map[string]*Security),
+		stream:      newStream(),
+
+		inputs:         make(map[string]*Input),
+		inputOverrides: make(map[string]any),
+	}
 }
 
 func (g *GoQuant) AddBars(bars []serie.Bar) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// only the bars completed by the new real bars are added
+	if g.transform != nil {
+		g.realBars = append(g.realBars, bars...)
+		transformed, sources := g.transform(g.realBars)
+
+		// the bars returned before must stay the same
+		n := len(g.bars)
+		if len(transformed) < n || (n > 0 && (sources[n-1] != g.sources[n-1] || transformed[n-1].Time != g.bars[n-1].Time)) {
+			panic("the BarTransform changed the bars it returned before")
+		}
+		bars, g.sources = transformed[n:], sources
+	}
+
 	g.bars = append(g.bars, bars...)
 
 	// storages
@@ -121,6 +243,21 @@ func (g *GoQuant) AddBars(bars []serie.Bar) {
 	g.volume.AddData(serie.ExtractField(bars, "Volume"))
 }
 
+// Transform makes Logic run on the bars built by transform from the bars added, e.g. serie.HeikinAshi(), while the
+// strategy orders are still filled against the real bars. Call it before adding bars
+//
+//	GQ.Transform(serie.Renko(serie.RenkoConfig{ATRLength: 14}))
+//	GQ.AddBars(bars)
+func (g *GoQuant) Transform(transform serie.BarTransform) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.bars) > 0 || len(g.realBars) > 0 {
+		panic("Transform must be called before AddBars")
+	}
+	g.transform = transform
+}
+
 func (g *GoQuant) NewStorage(label string, data *[]float64) serie.Serie {
 	if serie, exists := g.taStorage[label]; exists {
 		return serie
@@ -135,8 +272,34 @@ func (g *GoQuant) NewEmptyStorage(label string) serie.Serie {
 	return g.NewStorage(label, &[]float64{})
 }
 
+// NewStrategy attaches a backtesting strategy, its orders are filled against each bar before Logic runs on it.
+// nil config uses the defaults
+func (g *GoQuant) NewStrategy(config *strategy.Config) *strategy.Strategy {
+	g.strategy = strategy.New(g, config)
+	return g.strategy
+}
+
+// Strategy returns the attached strategy, nil if NewStrategy wasn't called
+func (g *GoQuant) Strategy() *strategy.Strategy {
+	return g.strategy
+}
+
+// Report returns the strategy performance computed when Logic last finished, nil without a strategy
+func (g *GoQuant) Report() *strategy.Report {
+	return g.report
+}
+
+// NewWrapper returns a serie of f, the indicators called by f without a label are told apart by CallSite for each
+// evaluation of f
 func (g *GoQuant) NewWrapper(f func() float64) serie.Serie {
-	return serie.NewWrapper(g, f)
+	id := g.newWrapperID(f)
+
+	return serie.NewWrapper(g, func() float64 {
+		g.frames = append(g.frames, frame{owner: id})
+		value := f()
+		g.frames = g.frames[:len(g.frames)-1]
+		return value
+	})
 }
 
 func (g *GoQuant) BarIndex() int {
@@ -151,6 +314,44 @@ func (g *GoQuant) IncreaseFuncIndex(steps int) {
 func (g *GoQuant) DecreaseFuncIndex(steps int) {
 	g.loopFuncIndex -= steps
 }
+
+// GetCache returns the cached value of label at the given bar index
+func (g *GoQuant) GetCache(label string, index int) (float64, bool) {
+	value, exists := g.cache[label][index]
+	return value, exists
+}
+
+// SetCache stores the value of label at the given bar index
+func (g *GoQuant) SetCache(label string, index int, value float64) {
+	if g.cache[label] == nil {
+		g.cache[label] = make(map[int]float64)
+	}
+	g.cache[label][index] = value
+}
+
+// ResetCache drops every cached value of this instance
+func (g *GoQuant) ResetCache() {
+	g.cache = make(map[string]map[int]float64)
+}
+
+// EvictCache drops the cached values of the given labels
+func (g *GoQuant) EvictCache(labels ...string) {
+	for _, label := range labels {
+		delete(g.cache, label)
+	}
+}
+
+// EvictCacheBefore drops cached values of bars older than index, keeps memory bounded on long or streaming runs
+func (g *GoQuant) EvictCacheBefore(index int) {
+	for _, values := range g.cache {
+		for i := range values {
+			if i < index {
+				delete(values, i)
+			}
+		}
+	}
+}
+
 func (g *GoQuant) IsFirstBar() bool {
 	return g.loopIndex == 0
 }
@@ -168,6 +369,7 @@ type PlotConfig struct {
 	Shift     int     `json:"shift,omitempty"`
 }
 
+type LogicF func(open, high, close, low, volume, time serie.Serie, ta TA, plot PlotF, line LineF, vline VLineF, hline HLineF)
 type PlotF func(value float64, config *PlotConfig, label ...string)
 type LineF func(p1, p2 Point, config *LineConfig)
 type HLineF func(value float64, config *LineConfig)
@@ -286,13 +488,37 @@ func (g *GoQuant) line(p1, p2 Point, config *LineConfig) {
 	g.lineStorage = append(g.lineStorage, LineData{Type: Segment, Config: *config, Points: []Point{p1, p2}})
 }
 
+// Annotate marks the current bar (shifted by config.Shift) with an arrow pointing at value and text above it,
+// e.g. a candlestick pattern over the high of the bar
+func (g *GoQuant) Annotate(text string, value float64, config *LineConfig) {
+	if config == nil {
+		config = &LineConfig{}
+	}
+
+	if config.Location == "" {
+		config.Location = "candle_pane"
+	}
+
+	index := g.loopIndex + config.Shift
+	if index < 0 || index >= len(g.bars) {
+		return
+	}
+
+	x := g.bars[index].Time
+
+	g.lineStorage = append(g.lineStorage, LineData{Type: Annotation, Config: *config, Points: []Point{{X: x, Y: value}}, Text: text})
+}
+
 func (g *GoQuant) plot(value float64, config *PlotConfig, label ...string) {
 	lbl := ""
+	location := ""
 	if len(label) > 0 {
-		lbl = label[0]
+		lbl, location = label[0], label[0]
 	} else {
-		_, _, line, _ := runtime.Caller(1)
-		lbl = fmt.Sprint(line)
+		// plots of the same line share their pane
+		var line int
+		lbl, line = g.callSite(1)
+		location = fmt.Sprint(line)
 	}
 
 	if config == nil {
@@ -300,7 +526,7 @@ func (g *GoQuant) plot(value float64, config *PlotConfig, label ...string) {
 	}
 
 	if config.Location == "" {
-		config.Location = lbl
+		config.Location = location
 	}
 
 	var _value *float64
@@ -427,6 +653,7 @@ func (g *GoQuant) RemoveDuplicatesFromStraightLines() {
 		} else if line.Type == VerticalStraightLine {
 			key = fmt.Sprintf("%v|%v|%v", line.Type, line.Config, line.Points[0].X)
 		} else {
+			uniqueLines = append(uniqueLines, line)
 			continue
 		}
 
@@ -439,8 +666,8 @@ func (g *GoQuant) RemoveDuplicatesFromStraightLines() {
 	g.lineStorage = uniqueLines
 }
 
-func (g *GoQuant) Logic(userFunc func(open, high, close, low, volume, time serie.Serie, ta TA, plot PlotF, line LineF, vline VLineF, hline HLineF)) {
-	ta := TA{
+func (g *GoQuant) ta() TA {
+	return TA{
 		Cross:      g.cross,
 		CrossOver:  g.crossOver,
 		CrossUnder: g.crossUnder,
@@ -456,8 +683,88 @@ func (g *GoQuant) Logic(userFunc func(open, high, close, low, volume, time serie
 		VWMA: g.vwma,
 		RMA:  g.rma,
 		RSI:  g.rsi,
+
+		Sum:   g.sum,
+		Stdev: g.stdev,
+
+		Variance:              g.variance,
+		Covariance:            g.covariance,
+		Correlation:           g.correlation,
+		Beta:                  g.beta,
+		ZScore:                g.zScore,
+		LinReg:                g.linreg,
+		LinRegSlope:           g.linregSlope,
+		LinRegIntercept:       g.linregIntercept,
+		PercentRank:           g.percentRank,
+		PercentileNearestRank: g.percentileNearestRank,
+		PercentileLinear:      g.percentileLinear,
+		Median:                g.median,
+
+		Highest:     g.highest,
+		Lowest:      g.lowest,
+		HighestBars: g.highestBars,
+		LowestBars:  g.lowestBars,
+
+		WMA:  g.wma,
+		HMA:  g.hma,
+		DEMA: g.dema,
+		TEMA: g.tema,
+		ALMA: g.alma,
+		KAMA: g.kama,
+		LSMA: g.lsma,
+		T3:   g.t3,
+		MA:   g.ma,
+
+		MACD:     g.macd,
+		BB:       g.bollinger,
+		Stoch:    g.stochastic,
+		StochRSI: g.stochRSI,
+
+		Keltner:              g.keltner,
+		Donchian:             g.donchianChannel,
+		ATRBands:             g.atrBands,
+		Chandelier:           g.chandelier,
+		HistoricalVolatility: g.historicalVolatility,
+
+		CCI:                g.cci,
+		WilliamsR:          g.williamsR,
+		ROC:                g.roc,
+		Momentum:           g.momentum,
+		TRIX:               g.trix,
+		UltimateOscillator: g.ultimateOscillator,
+		AwesomeOscillator:  g.awesomeOscillator,
+
+		DMI:        g.dmi,
+		SuperTrend: g.superTrend,
+		SAR:        g.sar,
+		Ichimoku:   g.ichimoku,
+
+		ZigZag:          g.zigZag,
+		MarketStructure: g.marketStructure,
+		PivotPoints:     g.pivotPoints,
+
+		OBV:          g.obv,
+		AccDist:      g.accDist,
+		CMF:          g.cmf,
+		MFI:          g.mfi,
+		VWAP:         g.vwap,
+		AnchoredVWAP: g.anchoredVWAP,
+
+		Patterns: g.patterns,
 	}
+}
+
+func (g *GoQuant) Logic(userFunc LogicF) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.run(userFunc)
+}
 
+// run is Logic, under the lock
+func (g *GoQuant) run(userFunc LogicF) {
+	g.logic = userFunc
+	ta := g.ta()
 	plot := g.plot
 	line := g.line
 	vline := g.vline
@@ -465,64 +772,96 @@ func (g *GoQuant) Logic(userFunc func(open, high, close, low, volume, time serie
 
 	endIndex := len(g.bars)
 	for ; g.loopIndex < endIndex; g.loopIndex++ {
+		g.resetFrames()
+
+		if g.strategy != nil {
+			g.processBar()
+		}
+
 		userFunc(g.open, g.high, g.close, g.low, g.volume, g.time, ta, plot, line, vline, hline)
 	}
 
 	g.FillTheGaps()
 	g.RemoveDuplicatesFromStraightLines()
-}
 
-func (g *GoQuant) Server(port int) error {
-	http.HandleFunc("/bars", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-
-		jsonData, err := json.Marshal(serie.ConvertToPointerBars(g.bars))
-		if err != nil {
-			fmt.Println(err)
-			http.Error(w, "Error converting to JSON", http.StatusInternalServerError)
-			return
-		}
-
-		w.Write(jsonData)
-	})
-
-	http.HandleFunc("/plots", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-
-		jsonData, err := json.Marshal(g.plotStorage)
-		if err != nil {
-			fmt.Println(err)
-			http.Error(w, "Error converting to JSON", http.StatusInternalServerError)
-			return
-		}
-
-		w.Write(jsonData)
-	})
+	if g.strategy != nil {
+		report := g.strategy.Report()
+		g.report = &report
+	}
 
-	http.HandleFunc("/lines", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
+	g.publish()
+}
+
+// Reset drops what Logic computed: storages, caches, plots, lines, the strategy orders, trades and report, and the
+// state of the securities, so the next Logic runs from the first bar again, e.g. with other inputs. The bars, the
+// inputs and the strategy config are kept. The chart reloads
+//
+//	GQ.SetInput("length", 20)
+//	GQ.Reset()
+//	GQ.Logic(myLogic)
+func (g *GoQuant) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.reset()
+}
+
+// reset is Reset, under the lock
+func (g *GoQuant) reset() {
+	g.loopIndex, g.loopFuncIndex, g.realIndex = 0, 0, 0
+	g.taStorage = make(map[string]serie.Serie)
+	g.plotStorage = make(map[string]PlotData)
+	g.lineStorage = nil
+	g.cache = make(map[string]map[int]float64)
+	g.extremes = make(map[string]*rollingExtreme)
+	g.identities = make(map[string]string)
+	g.frames = nil
+	g.report = nil
+	g.inputErr = nil
+
+	if g.strategy != nil {
+		g.strategy.Reset()
+	}
 
-		jsonData, err := json.Marshal(g.lineStorage)
-		if err != nil {
-			fmt.Println(err)
-			http.Error(w, "Error converting to JSON", http.StatusInternalServerError)
-			return
-		}
+	for _, security := range g.securities {
+		security.values = make(map[string][]float64)
+		security.gq.Reset()
+	}
+	for _, security := range g.loaded {
+		security.values = make(map[string][]float64)
+		security.gq.Reset()
+	}
 
-		w.Write(jsonData)
-	})
+	g.stream.reset()
+}
 
-	distSubFS, err := fs.Sub(assets.Dist, "chart/dist")
-	if err != nil {
-		return err
+// processBar fills the strategy orders against the real bars the current bar was built from. When it was built
+// on the same real bar as the previous one, only the state of the strategy is recorded
+func (g *GoQuant) processBar() {
+	if g.transform == nil {
+		g.strategy.ProcessBar(g.loopIndex, g.bars[g.loopIndex])
+		return
 	}
 
-	http.Handle("/", http.FileServer(http.FS(distSubFS)))
+	processed := false
+	for ; g.realIndex <= g.sources[g.loopIndex]; g.realIndex++ {
+		g.strategy.ProcessBar(g.loopIndex, g.realBars[g.realIndex])
+		processed = true
+	}
 
-	err = http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
-	if err != nil {
-		return err
+	if !processed {
+		nan := math.NaN()
+		g.strategy.ProcessBar(g.loopIndex, serie.Bar{Time: g.bars[g.loopIndex].Time, Open: nan, High: nan, Low: nan, Close: nan})
 	}
+}
 
-	return nil
+// OnBar returns a callback for serie.NewBarBuilder that appends each completed bar and runs userFunc on it only
+//
+//	builder, err := serie.NewBarBuilder(serie.BarBuilderConfig{Timeframe: "1m"}, GQ.OnBar(myLogic))
+//	builder.Add(serie.Trade{Time: t, Price: p, Size: s})
+func (g *GoQuant) OnBar(userFunc LogicF) func(bar serie.Bar) {
+	return func(bar serie.Bar) {
+		g.AddBars([]serie.Bar{bar})
+		g.Logic(userFunc)
+	}
 }
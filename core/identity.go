@@ -0,0 +1,197 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// IdentityMode sets what happens when the key of an indicator is reused with different parameters, e.g. when a call
+// inside a loop is skipped on some bars and the next calls take its key. Keys are checked only when it's not IdentityOff
+type IdentityMode int
+
+const (
+	IdentityOff IdentityMode = iota
+	IdentityWarn
+	IdentityPanic
+)
+
+// frame counts the calls made from each call site during one evaluation of the logic or of a wrapper
+type frame struct {
+	owner *wrapperID
+	calls map[uintptr]int
+}
+
+// site is a call site, its key is formatted once
+type site struct {
+	key  string
+	line int
+}
+
+// wrapperID tells apart the wrappers created by the same function literal
+type wrapperID struct {
+	parent *wrapperID
+	fn     uintptr
+	n      int
+	key    string // formatted on the first call
+}
+
+func (w *wrapperID) String() string {
+	if w == nil {
+		return ""
+	}
+	if w.key == "" {
+		w.key = fmt.Sprintf("%s%x#%d:", w.parent, w.fn, w.n)
+	}
+
+	return w.key
+}
+
+// SetIdentityMode checks the keys of the indicators from now on, see IdentityMode
+func (g *GoQuant) SetIdentityMode(mode IdentityMode) {
+	g.identityMode = mode
+}
+
+// SetLogger sets where the IdentityWarn warnings go, the standard logger of the log package by default
+func (g *GoQuant) SetLogger(logger *log.Logger) {
+	g.logger = logger
+}
+
+// CallSite returns a key for the call made skip frames above the caller, like runtime.Caller: its line and program
+// counter, so two calls on the same line or on the same line of different files differ, followed by the number of
+// calls made from there before in the current bar, so the calls made in a loop differ too. Calls made while a wrapper
+// created by GoQuant.NewWrapper is evaluated are counted for each evaluation and prefixed with the wrapper, so
+// evaluating it again or on previous bars gives the same keys.
+// The keys stay the same from a bar to the next as long as the calls are made in the same order
+func (g *GoQuant) CallSite(skip int) string {
+	key, _ := g.callSite(skip + 1)
+	return key
+}
+
+// callSite returns the key of CallSite and the line of the call. Only the program counter is read on each call,
+// the line and the key of the site are looked up once
+func (g *GoQuant) callSite(skip int) (string, int) {
+	var pcs [1]uintptr
+	runtime.Callers(skip+2, pcs[:])
+	pc := pcs[0]
+
+	s, exists := g.sites[pc]
+	if !exists {
+		frame, _ := runtime.CallersFrames(pcs[:]).Next()
+		s = site{key: fmt.Sprintf("%d@%x", frame.Line, frame.PC), line: frame.Line}
+		g.sites[pc] = s
+	}
+
+	f := g.frame()
+	n := f.calls[pc]
+	f.calls[pc]++
+
+	key := f.owner.String() + s.key
+	if n > 0 {
+		key += "#" + strconv.Itoa(n)
+	}
+
+	return key, s.line
+}
+
+// frame returns the current frame, Logic starts a new one on each bar
+func (g *GoQuant) frame() *frame {
+	if len(g.frames) == 0 {
+		g.frames = append(g.frames, frame{})
+	}
+
+	f := &g.frames[len(g.frames)-1]
+	if f.calls == nil {
+		f.calls = make(map[uintptr]int)
+	}
+
+	return f
+}
+
+// resetFrames starts counting the calls over, before running the logic on a bar
+func (g *GoQuant) resetFrames() {
+	g.frames = append(g.frames[:0], frame{})
+}
+
+// newWrapperID identifies a wrapper of f from the function literal and the wrappers of it created before in the
+// current frame
+func (g *GoQuant) newWrapperID(f func() float64) *wrapperID {
+	fn := reflect.ValueOf(f).Pointer()
+
+	current := g.frame()
+	n := current.calls[fn]
+	current.calls[fn]++
+
+	return &wrapperID{parent: current.owner, fn: fn, n: n}
+}
+
+// labelOf returns the storage key of an indicator: name followed by label, or by the call site of the indicator
+// without label, and by params
+func (g *GoQuant) labelOf(name string, label []string, params ...any) string {
+	lbl := name
+	if len(label) > 0 {
+		lbl += label[0]
+	} else {
+		lbl += g.CallSite(2)
+	}
+
+	if len(params) == 0 {
+		return lbl
+	}
+
+	signature := signatureOf(params)
+	g.checkIdentity(lbl, signature)
+
+	return lbl + signature
+}
+
+// signatureOf formats the params of an indicator like fmt.Sprint, "/" before each
+func signatureOf(params []any) string {
+	var signature strings.Builder
+	for _, param := range params {
+		signature.WriteByte('/')
+		switch p := param.(type) {
+		case float64:
+			signature.WriteString(strconv.FormatFloat(p, 'g', -1, 64))
+		case int:
+			signature.WriteString(strconv.Itoa(p))
+		default:
+			fmt.Fprint(&signature, p)
+		}
+	}
+
+	return signature.String()
+}
+
+// checkIdentity warns or panics, depending on the IdentityMode, when lbl was used before with another signature
+func (g *GoQuant) checkIdentity(lbl, signature string) {
+	if g.identityMode == IdentityOff {
+		return
+	}
+
+	// "" once warned
+	first, exists := g.identities[lbl]
+	if !exists {
+		g.identities[lbl] = signature
+		return
+	}
+	if first == "" || first == signature {
+		return
+	}
+
+	msg := fmt.Sprintf("indicator key %q reused with parameters %s, first used with %s; pass a label to tell the calls apart",
+		lbl, signature, first)
+	if g.identityMode == IdentityPanic {
+		panic(msg)
+	}
+
+	g.identities[lbl] = ""
+	if g.logger != nil {
+		g.logger.Println(msg)
+	} else {
+		log.Println(msg)
+	}
+}
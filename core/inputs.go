@@ -0,0 +1,269 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Input is a parameter declared by the logic, its value can be set from the command line, a JSON file or the chart.
+// Numbers are float64, enums are strings
+type Input struct {
+	Name        string   `json:"name"`
+	Kind        string   `json:"kind"` // "int", "float", "bool" or "enum"
+	Value       any      `json:"value"`
+	Default     any      `json:"default"`
+	Min         float64  `json:"min"`
+	Max         float64  `json:"max"` // no range unless Max > Min
+	Step        float64  `json:"step"`
+	Options     []string `json:"options,omitempty"` // of an enum
+	Description string   `json:"description,omitempty"`
+}
+
+// InputConfig sets the range of a number input, and the description of any input, nil for none
+type InputConfig struct {
+	Min         float64
+	Max         float64
+	Step        float64 // 1 for ints by default, a tenth of the range for floats
+	Description string
+}
+
+// InputInt declares an int input, or returns its value once declared. Call it from the logic, on every bar
+//
+//	length := GQ.InputInt("length", 14, &gq.InputConfig{Min: 2, Max: 50, Description: "RSI length"})
+func (g *GoQuant) InputInt(name string, value int, config *InputConfig) int {
+	return int(g.input(name, "int", float64(value), nil, config).(float64))
+}
+
+// InputFloat declares a float input, or returns its value once declared
+func (g *GoQuant) InputFloat(name string, value float64, config *InputConfig) float64 {
+	return g.input(name, "float", value, nil, config).(float64)
+}
+
+// InputBool declares a bool input, or returns its value once declared
+func (g *GoQuant) InputBool(name string, value bool, config *InputConfig) bool {
+	return g.input(name, "bool", value, nil, config).(bool)
+}
+
+// InputEnum declares an input taking one of options, or returns its value once declared
+//
+//	kind := GQ.InputEnum("ma", "ema", gq.MAKinds, nil)
+func (g *GoQuant) InputEnum(name string, value string, options []string, config *InputConfig) string {
+	return g.input(name, "enum", value, options, config).(string)
+}
+
+// input returns the value of the input, declared with value unless it was set before
+func (g *GoQuant) input(name, kind string, value any, options []string, config *InputConfig) any {
+	if in, exists := g.inputs[name]; exists {
+		if in.Kind != kind {
+			panic(fmt.Sprintf("input %q declared as %s and %s", name, in.Kind, kind))
+		}
+		return in.Value
+	}
+
+	c := InputConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.Step == 0 && kind == "int" {
+		c.Step = 1
+	}
+	if c.Step == 0 && kind == "float" && c.Max > c.Min {
+		c.Step = (c.Max - c.Min) / 10
+	}
+
+	in := &Input{
+		Name:        name,
+		Kind:        kind,
+		Value:       value,
+		Default:     value,
+		Min:         c.Min,
+		Max:         c.Max,
+		Step:        c.Step,
+		Options:     options,
+		Description: c.Description,
+	}
+
+	// an invalid value is dropped for the default, see InputError
+	if override, exists := g.inputOverrides[name]; exists {
+		if v, err := in.parse(override); err != nil {
+			delete(g.inputOverrides, name)
+			g.inputErr = errors.Join(g.inputErr, err)
+		} else {
+			in.Value = v
+		}
+	}
+
+	g.inputs[name] = in
+	g.inputOrder = append(g.inputOrder, name)
+	return in.Value
+}
+
+// parse converts value, a string from the command line, a JSON value or a Go value, to the kind of the input
+func (in *Input) parse(value any) (any, error) {
+	invalid := fmt.Errorf("input %q: %v is not a valid %s", in.Name, value, in.Kind)
+
+	var result any
+	switch v := value.(type) {
+	case string:
+		switch in.Kind {
+		case "enum":
+			result = v
+		case "bool":
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, invalid
+			}
+			result = b
+		default:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, invalid
+			}
+			result = f
+		}
+	case bool:
+		result = v
+	case int:
+		result = float64(v)
+	case float64:
+		result = v
+	default:
+		return nil, invalid
+	}
+
+	switch in.Kind {
+	case "bool":
+		if _, ok := result.(bool); !ok {
+			return nil, invalid
+		}
+	case "enum":
+		s, ok := result.(string)
+		known := false
+		for _, option := range in.Options {
+			known = known || option == s
+		}
+		if !ok || !known {
+			return nil, fmt.Errorf("input %q: %v is not one of %v", in.Name, value, in.Options)
+		}
+	default:
+		f, ok := result.(float64)
+		if !ok || math.IsNaN(f) || (in.Kind == "int" && f != math.Trunc(f)) {
+			return nil, invalid
+		}
+		if in.Max > in.Min && (f < in.Min || f > in.Max) {
+			return nil, fmt.Errorf("input %q: %v is out of [%v, %v]", in.Name, value, in.Min, in.Max)
+		}
+	}
+
+	return result, nil
+}
+
+// Inputs returns the inputs declared so far, in the order of declaration. Call it outside the logic
+func (g *GoQuant) Inputs() []Input {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.inputList()
+}
+
+// inputList is Inputs, under the lock
+func (g *GoQuant) inputList() []Input {
+	inputs := make([]Input, len(g.inputOrder))
+	for i, name := range g.inputOrder {
+		inputs[i] = *g.inputs[name]
+	}
+
+	return inputs
+}
+
+// SetInput sets the value of an input, checked right away once declared, or when the logic declares it, see
+// InputError. The new value is used from the next run of Logic, see Reset
+func (g *GoQuant) SetInput(name string, value any) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.setInput(name, value)
+}
+
+// setInput is SetInput, under the lock
+func (g *GoQuant) setInput(name string, value any) error {
+	if in, exists := g.inputs[name]; exists {
+		v, err := in.parse(value)
+		if err != nil {
+			return err
+		}
+		in.Value = v
+	}
+
+	g.inputOverrides[name] = value
+	return nil
+}
+
+// InputError returns why the values set before their inputs were declared were invalid, found by the runs of Logic
+// since the last Reset, nil if they were all valid. Those inputs keep their default value
+//
+//	GQ.ParseInputArgs(os.Args[1:])
+//	GQ.Logic(myLogic)
+//	if err := GQ.InputError(); err != nil {
+//		panic(err)
+//	}
+func (g *GoQuant) InputError() error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.inputErr
+}
+
+// ParseInputArgs sets inputs from command line arguments: -name=value, --name=value, -name value, or -name alone
+// for true
+//
+//	err := GQ.ParseInputArgs(os.Args[1:])
+func (g *GoQuant) ParseInputArgs(args []string) error {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unexpected argument %q, expected -name=value", arg)
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !hasValue {
+			value = "true"
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				value = args[i+1]
+				i++
+			}
+		}
+
+		if err := g.SetInput(name, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadInputs sets inputs from a JSON object of names and values, e.g. {"length": 20, "ma": "hma"}
+func (g *GoQuant) LoadInputs(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]any{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	for name, value := range values {
+		if err := g.SetInput(name, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// PivotKinds lists the kinds accepted by PivotConfig.Kind
+var PivotKinds = []string{"classic", "fibonacci", "camarilla", "woodie"}
+
+// PivotConfig sets the kind of pivot points and the periods they're computed on, nil is classic daily pivots
+type PivotConfig struct {
+	Kind      string                // one of PivotKinds, case insensitive, "classic" by default
+	Timeframe string                // "1D" (default), "1W", "1M"...
+	Calendar  *serie.ResampleConfig // timezone and session the periods are aligned to
+}
+
+// PivotLevels are computed from the high, low and close of the previous period (and the open of the current one
+// for Woodie), they're NaN during the first period. Only Classic, Camarilla and Woodie pivots have R4 and S4
+type PivotLevels struct {
+	P  serie.Serie
+	R1 serie.Serie
+	R2 serie.Serie
+	R3 serie.Serie
+	R4 serie.Serie
+	S1 serie.Serie
+	S2 serie.Serie
+	S3 serie.Serie
+	S4 serie.Serie
+
+	period   serie.Serie // start of the current period
+	tf       serie.Timeframe
+	calendar *serie.ResampleConfig
+	g        *GoQuant
+}
+
+// Draw draws each level as a segment over the period it applies to, on the first bar of the period.
+// Call it on every bar
+func (p PivotLevels) Draw(line LineF, config *LineConfig) {
+	start := p.period.Get()
+	if start == p.period.G(1) {
+		return
+	}
+
+	g := p.g
+	from := g.bars[g.BarIndex()].Time
+	to := serie.BucketEnd(start, p.tf, p.calendar)
+
+	for _, level := range []serie.Serie{p.R4, p.R3, p.R2, p.R1, p.P, p.S1, p.S2, p.S3, p.S4} {
+		if value := level.Get(); !serie.NA(value) {
+			line(Point{X: from, Y: value}, Point{X: to, Y: value}, config)
+		}
+	}
+}
+
+// pivotLevels returns P, R1 to R4 and S1 to S4 of kind
+func pivotLevels(kind string, high, low, close, open float64) [9]float64 {
+	nan := math.NaN()
+	r := high - low
+	p := (high + low + close) / 3
+
+	switch kind {
+	case "fibonacci":
+		return [9]float64{p, p + 0.382*r, p + 0.618*r, p + r, nan, p - 0.382*r, p - 0.618*r, p - r, nan}
+	case "camarilla":
+		return [9]float64{
+			p, close + 1.1*r/12, close + 1.1*r/6, close + 1.1*r/4, close + 1.1*r/2,
+			close - 1.1*r/12, close - 1.1*r/6, close - 1.1*r/4, close - 1.1*r/2,
+		}
+	case "woodie":
+		p = (high + low + 2*open) / 4
+		r3, s3 := high+2*(p-low), low-2*(high-p)
+		return [9]float64{p, 2*p - low, p + r, r3, r3 + r, 2*p - high, p - r, s3, s3 - r}
+	}
+
+	return [9]float64{
+		p, 2*p - low, p + r, high + 2*(p-low), 3*p + high - 3*low,
+		2*p - high, p - r, low - 2*(high-p), 3*p - 3*high + low,
+	}
+}
+
+// pivotPoints aggregates the bars into periods as they come, so the levels of a period are known from its first bar
+func (g *GoQuant) pivotPoints(config *PivotConfig, label ...string) PivotLevels {
+	lbl := g.labelOf("pivots", label)
+
+	c := PivotConfig{}
+	if config != nil {
+		c = *config
+	}
+	kind := strings.ToLower(c.Kind)
+	if kind == "" {
+		kind = "classic"
+	}
+	if c.Timeframe == "" {
+		c.Timeframe = "1D"
+	}
+
+	known := false
+	for _, k := range PivotKinds {
+		known = known || k == kind
+	}
+	if !known {
+		panic(fmt.Sprintf("unknown pivot points %q, expected one of %v", kind, PivotKinds))
+	}
+
+	tf, err := serie.ParseTimeframe(c.Timeframe)
+	if err != nil {
+		panic(err)
+	}
+
+	periods := g.NewEmptyStorage(lbl + "period")
+	// high, low and close of the current period so far, and its open
+	highs := g.NewEmptyStorage(lbl + "high")
+	lows := g.NewEmptyStorage(lbl + "low")
+	closes := g.NewEmptyStorage(lbl + "close")
+	opens := g.NewEmptyStorage(lbl + "open")
+	// high, low and close of the previous period
+	prevHighs := g.NewEmptyStorage(lbl + "prevHigh")
+	prevLows := g.NewEmptyStorage(lbl + "prevLow")
+	prevCloses := g.NewEmptyStorage(lbl + "prevClose")
+
+	period := g.NewWrapper(func() float64 {
+		start := serie.BucketStart(g.time.Get(), tf, c.Calendar)
+		high, low, close, open := highs.G(1), lows.G(1), closes.G(1), opens.G(1)
+		prevHigh, prevLow, prevClose := prevHighs.G(1), prevLows.G(1), prevCloses.G(1)
+
+		if start != periods.G(1) {
+			prevHigh, prevLow, prevClose = high, low, close
+			high, low, close, open = math.NaN(), math.NaN(), math.NaN(), math.NaN()
+		}
+
+		// gap bars are skipped
+		if bar := g.candle(0); !serie.NA(bar.close) {
+			if serie.NA(open) {
+				open, high, low = bar.open, bar.high, bar.low
+			}
+			high, low, close = math.Max(high, bar.high), math.Min(low, bar.low), bar.close
+		}
+
+		*periods.Set(0) = start
+		*highs.Set(0) = high
+		*lows.Set(0) = low
+		*closes.Set(0) = close
+		*opens.Set(0) = open
+		*prevHighs.Set(0) = prevHigh
+		*prevLows.Set(0) = prevLow
+		*prevCloses.Set(0) = prevClose
+		return start
+	}).Cache(BUILT_IN + lbl)
+
+	level := func(i int) serie.Serie {
+		return g.NewWrapper(func() float64 {
+			period.Get()
+			return pivotLevels(kind, prevHighs.Get(), prevLows.Get(), prevCloses.Get(), opens.Get())[i]
+		})
+	}
+
+	return PivotLevels{
+		P:        level(0),
+		R1:       level(1),
+		R2:       level(2),
+		R3:       level(3),
+		R4:       level(4),
+		S1:       level(5),
+		S2:       level(6),
+		S3:       level(7),
+		S4:       level(8),
+		period:   period,
+		tf:       tf,
+		calendar: c.Calendar,
+		g:        g,
+	}
+}
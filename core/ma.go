@@ -0,0 +1,170 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// MAKinds lists the kinds accepted by TA.MA, e.g. to sweep them as a strategy parameter
+var MAKinds = []string{"sma", "ema", "rma", "wma", "vwma", "hma", "dema", "tema", "alma", "kama", "lsma", "t3"}
+
+// wma weights the last bar by length, the one before by length - 1 and so on
+func (g *GoQuant) wma(src serie.Serie, length float64) serie.Serie {
+	n := int(length)
+
+	return g.NewWrapper(func() float64 {
+		sum, norm := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			weight := float64(n - i)
+			sum += src.G(i) * weight
+			norm += weight
+		}
+
+		return sum / norm
+	})
+}
+
+// hma is the Hull moving average
+func (g *GoQuant) hma(src serie.Serie, length float64) serie.Serie {
+	diff := g.wma(src, math.Floor(length/2)).Mul(2.0).Sub(g.wma(src, length))
+	return g.wma(diff, math.Floor(math.Sqrt(length)))
+}
+
+func (g *GoQuant) dema(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("dema", label)
+
+	e1 := g.ema(src, length, lbl+"1")
+	e2 := g.ema(e1, length, lbl+"2")
+
+	return e1.Mul(2.0).Sub(e2)
+}
+
+func (g *GoQuant) tema(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("tema", label)
+
+	e1 := g.ema(src, length, lbl+"1")
+	e2 := g.ema(e1, length, lbl+"2")
+	e3 := g.ema(e2, length, lbl+"3")
+
+	return e1.Sub(e2).Mul(3.0).Add(e3)
+}
+
+// alma is the Arnaud Legoux moving average, a gaussian window centered at offset (0 to 1) of width length / sigma
+func (g *GoQuant) alma(src serie.Serie, length, offset, sigma float64) serie.Serie {
+	n := int(length)
+	m := offset * (length - 1)
+	s := length / sigma
+
+	weights := make([]float64, n)
+	norm := 0.0
+	for i := range weights {
+		weights[i] = math.Exp(-(float64(i) - m) * (float64(i) - m) / (2 * s * s))
+		norm += weights[i]
+	}
+
+	return g.NewWrapper(func() float64 {
+		sum := 0.0
+		for i, weight := range weights {
+			sum += src.G(n-1-i) * weight
+		}
+
+		return sum / norm
+	})
+}
+
+// kama is Kaufman's adaptive moving average, it follows src between the speeds of a fastLength and a slowLength EMA
+// depending on how efficiently src moved over the last length bars
+func (g *GoQuant) kama(src serie.Serie, length, fastLength, slowLength float64, label ...string) serie.Serie {
+	lbl := g.labelOf("kama", label)
+	moves := src.Sub(src.B(1)).Custom(abs)
+
+	fast := 2 / (fastLength + 1)
+	slow := 2 / (slowLength + 1)
+
+	return g.NewWrapper(func() float64 {
+		values := g.NewEmptyStorage(lbl)
+		prev := values.G(1)
+		x := src.Get()
+
+		noise, nans := g.rollingSum(moves, int(length), lbl+"noise", nil)
+
+		var res float64
+		if serie.NA(prev) || nans > 0 {
+			res = x
+		} else {
+			efficiency := 0.0
+			if noise != 0 {
+				efficiency = math.Abs(x-src.G(int(length))) / noise
+			}
+
+			sc := math.Pow(efficiency*(fast-slow)+slow, 2)
+			res = prev + sc*(x-prev)
+		}
+
+		*values.Set(0) = res
+		return res
+	}).Cache(BUILT_IN + lbl)
+}
+
+// lsma is the least squares moving average, the end of the linear regression line
+func (g *GoQuant) lsma(src serie.Serie, length float64) serie.Serie {
+	return g.linreg(src, length, 0)
+}
+
+// t3 is Tillson's T3, six chained EMAs combined with the volume factor (usually 0.7)
+func (g *GoQuant) t3(src serie.Serie, length, vfactor float64, label ...string) serie.Serie {
+	lbl := g.labelOf("t3", label)
+
+	e1 := g.ema(src, length, lbl+"1")
+	e2 := g.ema(e1, length, lbl+"2")
+	e3 := g.ema(e2, length, lbl+"3")
+	e4 := g.ema(e3, length, lbl+"4")
+	e5 := g.ema(e4, length, lbl+"5")
+	e6 := g.ema(e5, length, lbl+"6")
+
+	a := vfactor
+	c1 := -a * a * a
+	c2 := 3*a*a + 3*a*a*a
+	c3 := -6*a*a - 3*a - 3*a*a*a
+	c4 := 1 + 3*a + a*a*a + 3*a*a
+
+	return e6.Mul(c1).Add(e5.Mul(c2)).Add(e4.Mul(c3)).Add(e3.Mul(c4))
+}
+
+// ma returns the moving average of kind (one of MAKinds, case insensitive), ALMA, KAMA and T3 use their usual defaults
+func (g *GoQuant) ma(kind string, src serie.Serie, length float64, label ...string) serie.Serie {
+	kind = strings.ToLower(kind)
+	lbl := g.labelOf("ma", label) + kind
+
+	switch kind {
+	case "sma":
+		return g.sma(src, length, lbl)
+	case "ema":
+		return g.ema(src, length, lbl)
+	case "rma":
+		return g.rma(src, length, lbl)
+	case "wma":
+		return g.wma(src, length)
+	case "vwma":
+		return g.vwma(src, length, lbl)
+	case "hma":
+		return g.hma(src, length)
+	case "dema":
+		return g.dema(src, length, lbl)
+	case "tema":
+		return g.tema(src, length, lbl)
+	case "alma":
+		return g.alma(src, length, 0.85, 6)
+	case "kama":
+		return g.kama(src, length, 2, 30, lbl)
+	case "lsma":
+		return g.lsma(src, length)
+	case "t3":
+		return g.t3(src, length, 0.7, lbl)
+	}
+
+	panic(fmt.Sprintf("unknown moving average %q, expected one of %v", kind, MAKinds))
+}
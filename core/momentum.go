@@ -0,0 +1,54 @@
+package core
+
+import (
+	"github.com/Go-Quant/goquant/serie"
+)
+
+type MACDLines struct {
+	MACD   serie.Serie // fast EMA - slow EMA
+	Signal serie.Serie // EMA of MACD
+	Hist   serie.Serie // MACD - Signal
+}
+
+type Stochastic struct {
+	K serie.Serie
+	D serie.Serie // SMA of K
+}
+
+func (g *GoQuant) macd(src serie.Serie, fastLength, slowLength, signalLength float64, label ...string) MACDLines {
+	lbl := g.labelOf("macd", label)
+
+	macd := g.ema(src, fastLength, lbl+"fast").Sub(g.ema(src, slowLength, lbl+"slow"))
+	signal := g.ema(macd, signalLength, lbl+"signal")
+
+	return MACDLines{MACD: macd, Signal: signal, Hist: macd.Sub(signal)}
+}
+
+// stoch returns where src stands between the lowest low and the highest high of the last length bars, from 0 to 100
+func (g *GoQuant) stoch(src, high, low serie.Serie, length float64, lbl string) serie.Serie {
+	lowest := g.lowest(low, length, lbl+"lowest")
+	highest := g.highest(high, length, lbl+"highest")
+
+	return src.Sub(lowest).Mul(100.0).Div(highest.Sub(lowest))
+}
+
+// stochastic is the slow stochastic of the bars: K is the SMA of the raw stochastic, D the SMA of K
+func (g *GoQuant) stochastic(periodK, smoothK, periodD float64, label ...string) Stochastic {
+	lbl := g.labelOf("stoch", label)
+
+	k := g.sma(g.stoch(g.close, g.high, g.low, periodK, lbl), smoothK, lbl+"k")
+	d := g.sma(k, periodD, lbl+"d")
+
+	return Stochastic{K: k, D: d}
+}
+
+// stochRSI is the stochastic applied to the RSI of src
+func (g *GoQuant) stochRSI(src serie.Serie, lengthRSI, lengthStoch, smoothK, smoothD float64, label ...string) Stochastic {
+	lbl := g.labelOf("stochrsi", label)
+
+	rsi := g.rsi(src, lengthRSI, lbl+"rsi")
+	k := g.sma(g.stoch(rsi, rsi, rsi, lengthStoch, lbl), smoothK, lbl+"k")
+	d := g.sma(k, smoothD, lbl+"d")
+
+	return Stochastic{K: k, D: d}
+}
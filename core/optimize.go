@@ -0,0 +1,111 @@
+package core
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Go-Quant/goquant/strategy"
+)
+
+// OptimizeResult is the strategy report of one combination of input values
+type OptimizeResult struct {
+	Inputs map[string]any  `json:"inputs"`
+	Score  float64         `json:"score"`
+	Report strategy.Report `json:"report"`
+}
+
+// Optimize runs userFunc on every combination of the values of the inputs: the ranges of the number inputs by their
+// step, both bools and all the options of the enums. The number inputs without a range keep their value.
+// It returns the results best score first, objective scores a report, nil for the net profit. The inputs are set
+// back and the logic run again at the end, so the chart shows the inputs it started with. The server waits meanwhile
+//
+//	results := GQ.Optimize(myLogic, func(r strategy.Report) float64 { return r.Sharpe })
+func (g *GoQuant) Optimize(userFunc LogicF, objective func(report strategy.Report) float64) []OptimizeResult {
+	if g.strategy == nil {
+		panic("Optimize needs a strategy, see NewStrategy")
+	}
+	if objective == nil {
+		objective = func(report strategy.Report) float64 { return report.NetProfit }
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// declares the inputs
+	if len(g.inputs) == 0 {
+		g.reset()
+		g.run(userFunc)
+	}
+
+	inputs := g.inputList()
+	grid := make([][]any, len(inputs))
+	for i, in := range inputs {
+		grid[i] = in.values()
+	}
+
+	var results []OptimizeResult
+	combination := make([]int, len(inputs))
+	for {
+		values := map[string]any{}
+		for i, in := range inputs {
+			values[in.Name] = grid[i][combination[i]]
+			g.inputs[in.Name].Value = values[in.Name]
+		}
+
+		g.reset()
+		g.run(userFunc)
+		results = append(results, OptimizeResult{Inputs: values, Score: objective(*g.report), Report: *g.report})
+
+		// next combination, the last input first
+		i := len(combination) - 1
+		for ; i >= 0; i-- {
+			combination[i]++
+			if combination[i] < len(grid[i]) {
+				break
+			}
+			combination[i] = 0
+		}
+		if i < 0 {
+			break
+		}
+	}
+
+	for _, in := range inputs {
+		g.inputs[in.Name].Value = in.Value
+	}
+	g.reset()
+	g.run(userFunc)
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score || math.IsNaN(results[j].Score) && !math.IsNaN(results[i].Score)
+	})
+
+	return results
+}
+
+// values lists the values Optimize tries
+func (in Input) values() []any {
+	switch {
+	case in.Kind == "bool":
+		return []any{false, true}
+	case in.Kind == "enum":
+		values := make([]any, len(in.Options))
+		for i, option := range in.Options {
+			values[i] = option
+		}
+		return values
+	case in.Max > in.Min && in.Step > 0:
+		// from Min, so steps don't accumulate rounding errors
+		var values []any
+		for i := 0; ; i++ {
+			value := in.Min + float64(i)*in.Step
+			if value > in.Max+in.Step*1e-9 {
+				break
+			}
+			values = append(values, math.Min(value, in.Max))
+		}
+		return values
+	}
+
+	return []any{in.Value}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"math"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// cci is the Commodity Channel Index, the distance of src from its SMA in mean absolute deviations / 0.015
+func (g *GoQuant) cci(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("cci", label)
+	n := int(length)
+	mean := g.sma(src, length, lbl)
+
+	return g.NewWrapper(func() float64 {
+		m := mean.Get()
+
+		deviation := 0.0
+		for i := 0; i < n; i++ {
+			deviation += math.Abs(src.G(i) - m)
+		}
+		deviation /= length
+
+		return (src.Get() - m) / (0.015 * deviation)
+	})
+}
+
+// williamsR is Williams %R, where the close stands in the range of the last length bars, from -100 to 0
+func (g *GoQuant) williamsR(length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("wpr", label)
+	highest := g.highest(g.high, length, lbl)
+	lowest := g.lowest(g.low, length, lbl)
+
+	return g.NewWrapper(func() float64 {
+		max, min := highest.Get(), lowest.Get()
+		return 100 * (g.close.Get() - max) / (max - min)
+	})
+}
+
+// roc is the rate of change of src over length bars, in percent
+func (g *GoQuant) roc(src serie.Serie, length float64) serie.Serie {
+	n := int(length)
+
+	return g.NewWrapper(func() float64 {
+		prev := src.G(n)
+		return 100 * (src.Get() - prev) / prev
+	})
+}
+
+func (g *GoQuant) momentum(src serie.Serie, length float64) serie.Serie {
+	return src.Sub(src.B(int(length)))
+}
+
+// trix is the change of the triple EMA of log(src), times 10000 like Pine's TRIX
+func (g *GoQuant) trix(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("trix", label)
+
+	e1 := g.ema(src.Custom(math.Log), length, lbl+"1")
+	e2 := g.ema(e1, length, lbl+"2")
+	e3 := g.ema(e2, length, lbl+"3")
+
+	return e3.Sub(e3.B(1)).Mul(10000.0)
+}
+
+// ultimateOscillator weights the buying pressure of the fast, middle and slow lengths (usually 7, 14 and 28) 4:2:1,
+// from 0 to 100
+func (g *GoQuant) ultimateOscillator(fastLength, middleLength, slowLength float64, label ...string) serie.Serie {
+	lbl := g.labelOf("uo", label)
+
+	prevClose := g.close.B(1)
+	low := g.Min(g.low, prevClose)
+	buyingPressure := g.close.Sub(low)
+	trueRange := g.Max(g.high, prevClose).Sub(low)
+
+	average := func(length float64, name string) serie.Serie {
+		return g.sum(buyingPressure, length, lbl+name+"bp").Div(g.sum(trueRange, length, lbl+name+"tr"))
+	}
+
+	fast := average(fastLength, "fast")
+	middle := average(middleLength, "middle")
+	slow := average(slowLength, "slow")
+
+	return fast.Mul(4.0).Add(middle.Mul(2.0)).Add(slow).Mul(100.0).Div(7.0)
+}
+
+// awesomeOscillator is the SMA of hl2 over fastLength minus the one over slowLength, usually 5 and 34
+func (g *GoQuant) awesomeOscillator(fastLength, slowLength float64, label ...string) serie.Serie {
+	lbl := g.labelOf("ao", label)
+	hl2 := g.high.Add(g.low).Div(2.0)
+
+	return g.sma(hl2, fastLength, lbl+"fast").Sub(g.sma(hl2, slowLength, lbl+"slow"))
+}
@@ -0,0 +1,243 @@
+package core
+
+import (
+	"math"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// PatternConfig sets the thresholds of the candlestick patterns as shares of the range (high - low) of a bar,
+// zero values use the defaults
+type PatternConfig struct {
+	DojiBody   float64 // a doji body is under this, 0.05
+	SmallBody  float64 // a small body (hammer, stars, harami) is under this, 0.3
+	LongBody   float64 // a long body (engulfing, stars, soldiers) is over this, 0.6
+	ShortWick  float64 // the wick a hammer or a shooting star doesn't have is under this, 0.1
+	WickFactor float64 // the long wick of a hammer or a shooting star is this many times the body, 2
+
+	// over 0, reversal patterns need a trend on their first bar: the close under SMA(close, TrendLength)
+	// for the bullish ones, over it for the bearish ones. Pine's built-in patterns use 50
+	TrendLength float64
+}
+
+// CandlePatterns are True on the last bar of the pattern, False otherwise
+type CandlePatterns struct {
+	BullishEngulfing serie.Serie
+	BearishEngulfing serie.Serie
+	Hammer           serie.Serie
+	ShootingStar     serie.Serie
+
+	Doji           serie.Serie
+	DragonflyDoji  serie.Serie
+	GravestoneDoji serie.Serie
+	LongLeggedDoji serie.Serie
+
+	MorningStar        serie.Serie
+	EveningStar        serie.Serie
+	ThreeWhiteSoldiers serie.Serie
+	ThreeBlackCrows    serie.Serie
+	BullishHarami      serie.Serie
+	BearishHarami      serie.Serie
+	Piercing           serie.Serie
+	DarkCloudCover     serie.Serie
+
+	InsideBar  serie.Serie
+	OutsideBar serie.Serie
+
+	names []string
+	all   []serie.Serie
+}
+
+// Matches returns the names of the patterns found on the current bar, e.g. to annotate it
+func (p CandlePatterns) Matches() []string {
+	var names []string
+	for i, pattern := range p.all {
+		if pattern.Get() == True {
+			names = append(names, p.names[i])
+		}
+	}
+	return names
+}
+
+type candle struct {
+	open, high, low, close float64
+}
+
+func (c candle) body() float64      { return math.Abs(c.close - c.open) }
+func (c candle) span() float64      { return c.high - c.low }
+func (c candle) top() float64       { return math.Max(c.open, c.close) }
+func (c candle) bottom() float64    { return math.Min(c.open, c.close) }
+func (c candle) middle() float64    { return (c.open + c.close) / 2 }
+func (c candle) upperWick() float64 { return c.high - c.top() }
+func (c candle) lowerWick() float64 { return c.bottom() - c.low }
+func (c candle) isUp() bool         { return c.close > c.open }
+func (c candle) isDown() bool       { return c.close < c.open }
+
+// candle returns the bar back bars before the current one
+func (g *GoQuant) candle(back int) candle {
+	return candle{open: g.open.G(back), high: g.high.G(back), low: g.low.G(back), close: g.close.G(back)}
+}
+
+// patterns recognizes the classic candlestick patterns on open, high, low and close. Comparisons with NaN are false,
+// so nothing is found on or right after gap bars
+func (g *GoQuant) patterns(config *PatternConfig, label ...string) CandlePatterns {
+	lbl := g.labelOf("patterns", label)
+
+	c := PatternConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.DojiBody == 0 {
+		c.DojiBody = 0.05
+	}
+	if c.SmallBody == 0 {
+		c.SmallBody = 0.3
+	}
+	if c.LongBody == 0 {
+		c.LongBody = 0.6
+	}
+	if c.ShortWick == 0 {
+		c.ShortWick = 0.1
+	}
+	if c.WickFactor == 0 {
+		c.WickFactor = 2
+	}
+
+	isDoji := func(b candle) bool { return b.span() > 0 && b.body() <= c.DojiBody*b.span() }
+	isSmall := func(b candle) bool { return b.span() > 0 && b.body() <= c.SmallBody*b.span() }
+	isLong := func(b candle) bool { return b.span() > 0 && b.body() >= c.LongBody*b.span() }
+
+	var trend serie.Serie
+	if c.TrendLength > 0 {
+		trend = g.sma(g.close, c.TrendLength, lbl+"trend")
+	}
+	isDownTrend := func(back int) bool {
+		return trend == nil || g.close.G(back) < trend.G(back)
+	}
+	isUpTrend := func(back int) bool {
+		return trend == nil || g.close.G(back) > trend.G(back)
+	}
+
+	// pattern returns a serie of match, called with the last bars of the pattern, the current one first
+	pattern := func(bars int, match func(b []candle) bool) serie.Serie {
+		return g.NewWrapper(func() float64 {
+			b := make([]candle, bars)
+			for i := range b {
+				b[i] = g.candle(i)
+			}
+
+			if match(b) {
+				return True
+			}
+			return False
+		})
+	}
+
+	p := CandlePatterns{
+		BullishEngulfing: pattern(2, func(b []candle) bool {
+			return b[1].isDown() && b[0].isUp() && b[0].close >= b[1].open && b[0].open <= b[1].close &&
+				b[0].body() > b[1].body() && isDownTrend(1)
+		}),
+		BearishEngulfing: pattern(2, func(b []candle) bool {
+			return b[1].isUp() && b[0].isDown() && b[0].close <= b[1].open && b[0].open >= b[1].close &&
+				b[0].body() > b[1].body() && isUpTrend(1)
+		}),
+		Hammer: pattern(1, func(b []candle) bool {
+			return isSmall(b[0]) && b[0].body() > 0 && b[0].lowerWick() >= c.WickFactor*b[0].body() &&
+				b[0].upperWick() <= c.ShortWick*b[0].span() && isDownTrend(0)
+		}),
+		ShootingStar: pattern(1, func(b []candle) bool {
+			return isSmall(b[0]) && b[0].body() > 0 && b[0].upperWick() >= c.WickFactor*b[0].body() &&
+				b[0].lowerWick() <= c.ShortWick*b[0].span() && isUpTrend(0)
+		}),
+
+		Doji: pattern(1, func(b []candle) bool {
+			return isDoji(b[0])
+		}),
+		DragonflyDoji: pattern(1, func(b []candle) bool {
+			return isDoji(b[0]) && b[0].upperWick() <= c.ShortWick*b[0].span()
+		}),
+		GravestoneDoji: pattern(1, func(b []candle) bool {
+			return isDoji(b[0]) && b[0].lowerWick() <= c.ShortWick*b[0].span()
+		}),
+		LongLeggedDoji: pattern(1, func(b []candle) bool {
+			return isDoji(b[0]) && b[0].upperWick() >= b[0].span()/3 && b[0].lowerWick() >= b[0].span()/3
+		}),
+
+		MorningStar: pattern(3, func(b []candle) bool {
+			return isLong(b[2]) && b[2].isDown() && isSmall(b[1]) && b[1].top() <= b[2].close &&
+				isLong(b[0]) && b[0].isUp() && b[0].close > b[2].middle() && isDownTrend(2)
+		}),
+		EveningStar: pattern(3, func(b []candle) bool {
+			return isLong(b[2]) && b[2].isUp() && isSmall(b[1]) && b[1].bottom() >= b[2].close &&
+				isLong(b[0]) && b[0].isDown() && b[0].close < b[2].middle() && isUpTrend(2)
+		}),
+		ThreeWhiteSoldiers: pattern(3, func(b []candle) bool {
+			for i := 0; i < 3; i++ {
+				if !b[i].isUp() || !isLong(b[i]) {
+					return false
+				}
+			}
+			// each one opens within the body of the last one and closes higher
+			for i := 0; i < 2; i++ {
+				if b[i].open < b[i+1].open || b[i].open > b[i+1].close || b[i].close <= b[i+1].close {
+					return false
+				}
+			}
+			return isDownTrend(2)
+		}),
+		ThreeBlackCrows: pattern(3, func(b []candle) bool {
+			for i := 0; i < 3; i++ {
+				if !b[i].isDown() || !isLong(b[i]) {
+					return false
+				}
+			}
+			for i := 0; i < 2; i++ {
+				if b[i].open > b[i+1].open || b[i].open < b[i+1].close || b[i].close >= b[i+1].close {
+					return false
+				}
+			}
+			return isUpTrend(2)
+		}),
+		BullishHarami: pattern(2, func(b []candle) bool {
+			return isLong(b[1]) && b[1].isDown() && b[0].isUp() && isSmall(b[0]) &&
+				b[0].open >= b[1].close && b[0].close <= b[1].open && isDownTrend(1)
+		}),
+		BearishHarami: pattern(2, func(b []candle) bool {
+			return isLong(b[1]) && b[1].isUp() && b[0].isDown() && isSmall(b[0]) &&
+				b[0].open <= b[1].close && b[0].close >= b[1].open && isUpTrend(1)
+		}),
+		Piercing: pattern(2, func(b []candle) bool {
+			return isLong(b[1]) && b[1].isDown() && b[0].isUp() && b[0].open < b[1].close &&
+				b[0].close > b[1].middle() && b[0].close < b[1].open && isDownTrend(1)
+		}),
+		DarkCloudCover: pattern(2, func(b []candle) bool {
+			return isLong(b[1]) && b[1].isUp() && b[0].isDown() && b[0].open > b[1].close &&
+				b[0].close < b[1].middle() && b[0].close > b[1].open && isUpTrend(1)
+		}),
+
+		InsideBar: pattern(2, func(b []candle) bool {
+			return b[0].high < b[1].high && b[0].low > b[1].low
+		}),
+		OutsideBar: pattern(2, func(b []candle) bool {
+			return b[0].high > b[1].high && b[0].low < b[1].low
+		}),
+	}
+
+	p.names = []string{
+		"Bullish Engulfing", "Bearish Engulfing", "Hammer", "Shooting Star",
+		"Doji", "Dragonfly Doji", "Gravestone Doji", "Long-Legged Doji",
+		"Morning Star", "Evening Star", "Three White Soldiers", "Three Black Crows",
+		"Bullish Harami", "Bearish Harami", "Piercing", "Dark Cloud Cover",
+		"Inside Bar", "Outside Bar",
+	}
+	p.all = []serie.Serie{
+		p.BullishEngulfing, p.BearishEngulfing, p.Hammer, p.ShootingStar,
+		p.Doji, p.DragonflyDoji, p.GravestoneDoji, p.LongLeggedDoji,
+		p.MorningStar, p.EveningStar, p.ThreeWhiteSoldiers, p.ThreeBlackCrows,
+		p.BullishHarami, p.BearishHarami, p.Piercing, p.DarkCloudCover,
+		p.InsideBar, p.OutsideBar,
+	}
+
+	return p
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// Security evaluates expressions on higher timeframe bars and aligns the results to the bars of its parent.
+// Lookahead is off: on each parent bar only the last completed higher timeframe bar is visible
+type Security struct {
+	gq        *GoQuant // evaluates on the higher timeframe bars
+	parent    *GoQuant
+	timeframe *serie.Timeframe // nil when the bars were given
+	config    *serie.ResampleConfig
+	synced    int // parent bars already resampled
+	pending   int // first parent bar of the higher timeframe bar not completed yet
+	ends      []float64
+	values    map[string][]float64
+}
+
+// Security resamples the parent bars into the timeframe ("15m", "4h", "1D", "1W", "1M"), aligned to the optional
+// calendar config, and returns the same Security for the same arguments, so it can be called from the logic of every bar
+func (g *GoQuant) Security(timeframe string, config ...*serie.ResampleConfig) *Security {
+	var c *serie.ResampleConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+
+	key := timeframe
+	if c != nil {
+		key = fmt.Sprint(timeframe, *c)
+	}
+
+	if security, exists := g.securities[key]; exists {
+		return security
+	}
+
+	tf, err := serie.ParseTimeframe(timeframe)
+	if err != nil {
+		panic(err)
+	}
+
+	security := &Security{gq: New(), parent: g, timeframe: &tf, config: c, values: make(map[string][]float64)}
+	g.securities[key] = security
+	return security
+}
+
+// SecurityFromBars uses separately loaded higher timeframe bars, call it once, outside the logic
+func (g *GoQuant) SecurityFromBars(bars []serie.Bar) *Security {
+	security := &Security{gq: New(), parent: g, values: make(map[string][]float64)}
+	security.gq.AddBars(bars)
+
+	g.mu.Lock()
+	g.loaded = append(g.loaded, security)
+	g.mu.Unlock()
+
+	for i, bar := range bars {
+		if i+1 < len(bars) {
+			security.ends = append(security.ends, bars[i+1].Time)
+		} else if i > 0 {
+			security.ends = append(security.ends, bar.Time+bar.Time-bars[i-1].Time)
+		} else {
+			security.ends = append(security.ends, math.Inf(1))
+		}
+	}
+
+	return security
+}
+
+func (s *Security) Open() serie.Serie   { return s.gq.open }
+func (s *Security) High() serie.Serie   { return s.gq.high }
+func (s *Security) Close() serie.Serie  { return s.gq.close }
+func (s *Security) Low() serie.Serie    { return s.gq.low }
+func (s *Security) Volume() serie.Serie { return s.gq.volume }
+func (s *Security) Time() serie.Serie   { return s.gq.time }
+
+// TA returns the indicators evaluated on the higher timeframe bars
+func (s *Security) TA() TA {
+	return s.gq.ta()
+}
+
+// Eval runs f once per completed higher timeframe bar, f must use the series and TA of the Security.
+// The returned serie is aligned to the parent bars
+//
+//	daily := GQ.Security("1D")
+//	dailySma := daily.Eval(func() float64 { return daily.TA().SMA(daily.Close(), 20).Get() })
+func (s *Security) Eval(f func() float64, label ...string) serie.Serie {
+	lbl := ""
+	if len(label) > 0 {
+		lbl = label[0]
+	} else {
+		lbl = s.parent.CallSite(1)
+	}
+
+	return s.parent.NewWrapper(func() float64 {
+		index := s.parent.BarIndex() - s.parent.BarFuncIndex()
+		if index < 0 || index >= len(s.parent.bars) {
+			return math.NaN()
+		}
+
+		s.sync()
+		k := s.completed(index)
+		if k < 0 {
+			return math.NaN()
+		}
+
+		values := s.values[lbl]
+		for len(values) <= k {
+			s.gq.loopIndex = len(values)
+			s.gq.resetFrames()
+			values = append(values, f())
+		}
+		s.values[lbl] = values
+
+		return values[k]
+	})
+}
+
+// completed returns the last higher timeframe bar closed by the end of the parent bar at index, -1 if none
+func (s *Security) completed(index int) int {
+	bars := s.parent.bars
+	end := bars[index].Time
+	if len(bars) > 1 {
+		end += bars[1].Time - bars[0].Time
+	}
+
+	return sort.Search(len(s.ends), func(k int) bool { return s.ends[k] > end }) - 1
+}
+
+// sync appends the higher timeframe bars completed by newly added parent bars, resampling the parent bars from the
+// last incomplete higher timeframe bar only
+func (s *Security) sync() {
+	bars := s.parent.bars
+	if s.timeframe == nil || s.synced == len(bars) || len(bars) == 0 {
+		return
+	}
+	s.synced = len(bars)
+
+	end := bars[len(bars)-1].Time
+	if len(bars) > 1 {
+		end += bars[1].Time - bars[0].Time
+	}
+
+	resampled, err := serie.Resample(bars[s.pending:], s.timeframe.String(), s.config)
+	if err != nil {
+		panic(err)
+	}
+
+	var completed []serie.Bar
+	for _, bar := range resampled {
+		barEnd := serie.BucketEnd(bar.Time, *s.timeframe, s.config)
+		if barEnd > end {
+			break
+		}
+		if len(s.ends) == 0 || bar.Time >= s.ends[len(s.ends)-1] {
+			completed = append(completed, bar)
+			s.ends = append(s.ends, barEnd)
+		}
+	}
+
+	if len(completed) == 0 {
+		return
+	}
+	s.gq.AddBars(completed)
+
+	// the parent bars before the end of the last completed bar won't be resampled again
+	last := s.ends[len(s.ends)-1]
+	for s.pending < len(bars) && bars[s.pending].Time < last {
+		s.pending++
+	}
+}
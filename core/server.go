@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"time"
+
+	assets "github.com/Go-Quant/goquant"
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// shutdownTimeout bounds how long Serve waits for in-flight requests once its context is done
+const shutdownTimeout = 5 * time.Second
+
+// Handler returns the chart and its API on a new ServeMux, every instance gets its own.
+// The chart uses relative URLs, so it can be mounted under a prefix:
+//
+//	mux.Handle("/btc/", http.StripPrefix("/btc", GQ.Handler()))
+func (g *GoQuant) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/bars", g.serveJSON(func() interface{} { return serie.ConvertToPointerBars(g.bars) }))
+	mux.HandleFunc("/plots", g.serveJSON(func() interface{} { return g.plotStorage }))
+	mux.HandleFunc("/lines", g.serveJSON(func() interface{} { return g.lineStorage }))
+	mux.HandleFunc("/report", g.serveJSON(func() interface{} { return g.report }))
+	mux.HandleFunc("/inputs", g.serveInputs)
+	mux.HandleFunc("/stream", g.serveStream)
+
+	distSubFS, err := fs.Sub(assets.Dist, "chart/dist")
+	if err != nil {
+		panic(err)
+	}
+
+	mux.Handle("/", http.FileServer(http.FS(distSubFS)))
+	return mux
+}
+
+// Serve listens on addr (e.g. "127.0.0.1:3000" or ":3000") until ctx is done, then shuts down gracefully.
+// It returns nil after a shutdown
+func (g *GoQuant) Serve(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:    addr,
+		Handler: g.Handler(),
+		// cancels the open /stream requests on shutdown, Shutdown doesn't
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	// done releases the goroutine when ListenAndServe fails before ctx is done, e.g. when addr is in use
+	shutdown := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		timeout, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		shutdown <- server.Shutdown(timeout)
+	}()
+
+	err := server.ListenAndServe()
+	close(done)
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return <-shutdown
+}
+
+// Server serves on all interfaces until the process exits, see Serve for a bind address and shutdown
+func (g *GoQuant) Server(port int) error {
+	return g.Serve(context.Background(), fmt.Sprintf(":%d", port))
+}
+
+// serveJSON writes the value as JSON, read under the lock so Logic can run meanwhile
+func (g *GoQuant) serveJSON(value func() interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		g.mu.RLock()
+		jsonData, err := json.Marshal(value())
+		g.mu.RUnlock()
+		if err != nil {
+			fmt.Println(err)
+			http.Error(w, "Error converting to JSON", http.StatusInternalServerError)
+			return
+		}
+
+		w.Write(jsonData)
+	}
+}
+
+// serveInputs returns the inputs, or sets them from a JSON object of names and values posted by the chart and runs
+// the logic again. The values are set and the logic run under the lock, so the other requests never see the state
+// in between
+func (g *GoQuant) serveInputs(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		values := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		g.mu.Lock()
+		err := g.setInputs(values)
+		g.mu.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	g.serveJSON(func() interface{} { return g.inputList() })(w, r)
+}
+
+// setInputs sets all the values or none, then runs the last logic again
+func (g *GoQuant) setInputs(values map[string]any) error {
+	for name, value := range values {
+		if in, exists := g.inputs[name]; exists {
+			if _, err := in.parse(value); err != nil {
+				return err
+			}
+		}
+	}
+	for name, value := range values {
+		g.setInput(name, value)
+	}
+
+	if g.logic == nil {
+		return nil
+	}
+	g.reset()
+	g.run(g.logic)
+
+	return g.inputErr
+}
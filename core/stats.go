@@ -0,0 +1,255 @@
+package core
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// windowStats are the population statistics of two series over the last length bars
+type windowStats struct {
+	meanX, meanY float64
+	varX, varY   float64
+	cov          float64
+}
+
+// rollingStats keeps the running sums of x, y, x², y² and xy, so each bar costs O(1) like rollingSum.
+// ok is false while the window holds a NaN value of either serie
+func (g *GoQuant) rollingStats(x, y serie.Serie, length float64, lbl string) (stats windowStats, ok bool) {
+	n := int(length)
+
+	// sums of values shifted by the ones on the bar the window was last summed again,
+	// prices far from 0 would otherwise lose the variance to rounding
+	index := g.BarIndex() - g.BarFuncIndex()
+	shiftX, shiftY := serie.NZ(x.G(index%n)), serie.NZ(y.G(index%n))
+
+	products := g.NewWrapper(func() float64 {
+		return (x.Get() - shiftX) * (y.Get() - shiftY)
+	})
+
+	sumX, nansX := g.rollingSum(x, n, lbl+"x", func(v float64) float64 { return v - shiftX })
+	sumY, nansY := g.rollingSum(y, n, lbl+"y", func(v float64) float64 { return v - shiftY })
+	sumXX, _ := g.rollingSum(x, n, lbl+"xx", func(v float64) float64 { return (v - shiftX) * (v - shiftX) })
+	sumYY, _ := g.rollingSum(y, n, lbl+"yy", func(v float64) float64 { return (v - shiftY) * (v - shiftY) })
+	sumXY, _ := g.rollingSum(products, n, lbl+"xy", nil)
+
+	if nansX > 0 || nansY > 0 {
+		return windowStats{}, false
+	}
+
+	meanX, meanY := sumX/length, sumY/length
+	return windowStats{
+		meanX: shiftX + meanX,
+		meanY: shiftY + meanY,
+		varX:  math.Max(sumXX/length-meanX*meanX, 0),
+		varY:  math.Max(sumYY/length-meanY*meanY, 0),
+		cov:   sumXY/length - meanX*meanY,
+	}, true
+}
+
+// variance is the population (biased) variance, like Pine's ta.variance
+func (g *GoQuant) variance(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("variance", label, length)
+
+	return g.NewWrapper(func() float64 {
+		stats, ok := g.rollingStats(src, src, length, lbl)
+		if !ok {
+			return math.NaN()
+		}
+
+		return stats.varX
+	})
+}
+
+// stdev is the population (biased) standard deviation, like Pine's ta.stdev
+func (g *GoQuant) stdev(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("stdev", label, length)
+
+	return g.NewWrapper(func() float64 {
+		stats, ok := g.rollingStats(src, src, length, lbl)
+		if !ok {
+			return math.NaN()
+		}
+
+		return math.Sqrt(stats.varX)
+	})
+}
+
+func (g *GoQuant) covariance(src1, src2 serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("covariance", label, length)
+
+	return g.NewWrapper(func() float64 {
+		stats, ok := g.rollingStats(src1, src2, length, lbl)
+		if !ok {
+			return math.NaN()
+		}
+
+		return stats.cov
+	})
+}
+
+// correlation is the Pearson correlation coefficient, from -1 to 1
+func (g *GoQuant) correlation(src1, src2 serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("correlation", label, length)
+
+	return g.NewWrapper(func() float64 {
+		stats, ok := g.rollingStats(src1, src2, length, lbl)
+		if !ok {
+			return math.NaN()
+		}
+
+		return stats.cov / math.Sqrt(stats.varX*stats.varY)
+	})
+}
+
+// beta is how much src moves when benchmark moves by 1, pass returns (e.g. close.Div(close.B(1)).Sub(1.0)) rather than prices
+func (g *GoQuant) beta(src, benchmark serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("beta", label, length)
+
+	return g.NewWrapper(func() float64 {
+		stats, ok := g.rollingStats(src, benchmark, length, lbl)
+		if !ok {
+			return math.NaN()
+		}
+
+		return stats.cov / stats.varY
+	})
+}
+
+// zScore is how many standard deviations src is away from its mean over the last length bars
+func (g *GoQuant) zScore(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("zscore", label, length)
+
+	return g.NewWrapper(func() float64 {
+		stats, ok := g.rollingStats(src, src, length, lbl)
+		if !ok {
+			return math.NaN()
+		}
+
+		return (src.Get() - stats.meanX) / math.Sqrt(stats.varX)
+	})
+}
+
+// regression returns the slope per bar and the intercept (value on the oldest bar) of the least squares line
+// of the last n bars of src
+func regression(src serie.Serie, n int) (slope, intercept float64) {
+	length := float64(n)
+	sumX, sumY, sumXY, sumX2 := 0.0, 0.0, 0.0, 0.0
+	for i := 0; i < n; i++ {
+		x := float64(i) // 0 is the oldest bar
+		y := src.G(n - 1 - i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumX2 += x * x
+	}
+
+	slope = (length*sumXY - sumX*sumY) / (length*sumX2 - sumX*sumX)
+	intercept = sumY/length - slope*sumX/length
+	return slope, intercept
+}
+
+// linreg returns the least squares line of the last length bars of src, evaluated offset bars before the current one
+func (g *GoQuant) linreg(src serie.Serie, length float64, offset int) serie.Serie {
+	n := int(length)
+
+	return g.NewWrapper(func() float64 {
+		slope, intercept := regression(src, n)
+		return intercept + slope*float64(n-1-offset)
+	})
+}
+
+func (g *GoQuant) linregSlope(src serie.Serie, length float64) serie.Serie {
+	return g.NewWrapper(func() float64 {
+		slope, _ := regression(src, int(length))
+		return slope
+	})
+}
+
+// linregIntercept is the value of the least squares line on the oldest bar of the window
+func (g *GoQuant) linregIntercept(src serie.Serie, length float64) serie.Serie {
+	return g.NewWrapper(func() float64 {
+		_, intercept := regression(src, int(length))
+		return intercept
+	})
+}
+
+// sortedWindow returns the values of the last length bars of src that aren't NaN, sorted
+func sortedWindow(src serie.Serie, length int) []float64 {
+	values := make([]float64, 0, length)
+	for i := 0; i < length; i++ {
+		if v := src.G(i); !serie.NA(v) {
+			values = append(values, v)
+		}
+	}
+
+	sort.Float64s(values)
+	return values
+}
+
+// percentRank is the percentage of the previous length values that are lower than or equal to the current one,
+// NaN values are left out
+func (g *GoQuant) percentRank(src serie.Serie, length float64) serie.Serie {
+	return g.NewWrapper(func() float64 {
+		current := src.Get()
+		if serie.NA(current) {
+			return math.NaN()
+		}
+
+		count, below := 0, 0
+		for i := 1; i <= int(length); i++ {
+			v := src.G(i)
+			if serie.NA(v) {
+				continue
+			}
+
+			count++
+			if v <= current {
+				below++
+			}
+		}
+
+		if count == 0 {
+			return math.NaN()
+		}
+		return 100 * float64(below) / float64(count)
+	})
+}
+
+// percentileNearestRank returns the value of the last length bars that percentage (0 to 100) of them are lower
+// than or equal to, NaN values are left out
+func (g *GoQuant) percentileNearestRank(src serie.Serie, length, percentage float64) serie.Serie {
+	return g.NewWrapper(func() float64 {
+		values := sortedWindow(src, int(length))
+		if len(values) == 0 {
+			return math.NaN()
+		}
+
+		rank := int(math.Ceil(percentage / 100 * float64(len(values))))
+		rank = int(math.Min(math.Max(float64(rank), 1), float64(len(values))))
+		return values[rank-1]
+	})
+}
+
+// percentileLinear interpolates between the two values of the last length bars closest to percentage (0 to 100),
+// NaN values are left out
+func (g *GoQuant) percentileLinear(src serie.Serie, length, percentage float64) serie.Serie {
+	return g.NewWrapper(func() float64 {
+		values := sortedWindow(src, int(length))
+		if len(values) == 0 {
+			return math.NaN()
+		}
+
+		position := percentage / 100 * float64(len(values)-1)
+		position = math.Min(math.Max(position, 0), float64(len(values)-1))
+		lower := int(math.Floor(position))
+		upper := int(math.Ceil(position))
+
+		return values[lower] + (values[upper]-values[lower])*(position-float64(lower))
+	})
+}
+
+func (g *GoQuant) median(src serie.Serie, length float64) serie.Serie {
+	return g.percentileLinear(src, length, 50)
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// Update is the delta pushed to /stream subscribers each time Logic finishes
+type Update struct {
+	Bars  []serie.BarPointer  `json:"bars"`  // bars added since the last update
+	Index int                 `json:"index"` // bar index of the first bar in Bars
+	Plots map[string]PlotData `json:"plots"` // points appended since the last update, upsert them by index
+	Lines []LineData          `json:"lines"` // lines added since the last update
+}
+
+type stream struct {
+	mu          sync.Mutex
+	subscribers map[chan []byte]struct{}
+
+	// already published
+	bars  int
+	plots map[string]int
+	lines int
+}
+
+func newStream() *stream {
+	return &stream{subscribers: make(map[chan []byte]struct{}), plots: make(map[string]int)}
+}
+
+func (s *stream) subscribe() chan []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan []byte, 64)
+	s.subscribers[ch] = struct{}{}
+	return ch
+}
+
+func (s *stream) unsubscribe(ch chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.subscribers[ch]; exists {
+		delete(s.subscribers, ch)
+		close(ch)
+	}
+}
+
+// reset disconnects the subscribers, the chart reloads everything when it reconnects
+func (s *stream) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subscribers {
+		delete(s.subscribers, ch)
+		close(ch)
+	}
+
+	s.bars, s.plots, s.lines = 0, make(map[string]int), 0
+}
+
+// publish sends the changes since the last call to the subscribers
+func (g *GoQuant) publish() {
+	s := g.stream
+	update := Update{Index: s.bars, Plots: make(map[string]PlotData)}
+
+	update.Bars = serie.ConvertToPointerBars(g.bars[s.bars:])
+	s.bars = len(g.bars)
+
+	for label, plotData := range g.plotStorage {
+		published := s.plots[label]
+		if published < len(plotData.Data) {
+			update.Plots[label] = PlotData{Config: plotData.Config, Data: plotData.Data[published:]}
+		}
+		s.plots[label] = len(plotData.Data)
+	}
+
+	if s.lines < len(g.lineStorage) {
+		update.Lines = g.lineStorage[s.lines:]
+	}
+	s.lines = len(g.lineStorage)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.subscribers) == 0 {
+		return
+	}
+
+	jsonData, err := json.Marshal(update)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- jsonData:
+		default:
+			// too slow to keep up, the client reloads everything when it reconnects
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// serveStream pushes an Update as a Server-Sent Event each time Logic finishes
+func (g *GoQuant) serveStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := g.stream.subscribe()
+	defer g.stream.unsubscribe(ch)
+
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data, open := <-ch:
+			if !open {
+				return
+			}
+
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
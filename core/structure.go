@@ -0,0 +1,392 @@
+package core
+
+import (
+	"math"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// ZigZagConfig sets the pivots a ZigZag is made of, nil uses the defaults of TradingView's ZigZag
+type ZigZagConfig struct {
+	Depth     int     // bars on each side of a pivot high or low, 10
+	Deviation float64 // move from the last pivot needed to turn, in percent, 5
+	ATRLength float64 // over 0, Deviation is a multiple of ATR(ATRLength) instead of a percentage
+}
+
+// ZigZag joins alternating pivot highs and lows. The last pivot moves while its leg extends,
+// the ones before it are final
+type ZigZag struct {
+	Price     serie.Serie // price of the last pivot, NaN before the first one
+	Index     serie.Serie // bar index of the last pivot
+	Direction serie.Serie // 1 if the last pivot is a high, -1 if it's a low
+	PrevPrice serie.Serie // price of the pivot before the last one
+	PrevIndex serie.Serie
+
+	// the pivot before PrevIndex, and how many pivots were added on the bar: a bar can be both
+	// a pivot high and a pivot low
+	firstPrice serie.Serie
+	firstIndex serie.Serie
+	added      serie.Serie
+	g          *GoQuant
+}
+
+// Draw draws the legs that became final on the current bar, call it on every bar
+func (z ZigZag) Draw(line LineF, config *LineConfig) {
+	added := z.added.Get()
+	if serie.NA(added) || added == 0 {
+		return
+	}
+
+	g := z.g
+	point := func(index, price float64) Point {
+		return Point{X: g.bars[int(index)].Time, Y: price}
+	}
+
+	from, to := z.firstIndex.Get(), z.PrevIndex.Get()
+	if serie.NA(from) {
+		return
+	}
+
+	if before := z.PrevIndex.G(1); added == 2 && !serie.NA(before) {
+		line(point(before, z.PrevPrice.G(1)), point(from, z.firstPrice.Get()), config)
+	}
+	line(point(from, z.firstPrice.Get()), point(to, z.PrevPrice.Get()), config)
+}
+
+// zigZag turns on pivots of depth bars (like PivotHigh and PivotLow) that are far enough from the last pivot,
+// a pivot further in the direction of the last one replaces it
+func (g *GoQuant) zigZag(config *ZigZagConfig, label ...string) ZigZag {
+	lbl := g.labelOf("zigzag", label)
+
+	c := ZigZagConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.Depth == 0 {
+		c.Depth = 10
+	}
+	if c.Deviation == 0 {
+		c.Deviation = 5
+	}
+
+	// bar index + 1 of the pivots, 0 before the first ones
+	indexes := g.NewEmptyStorage(lbl + "index")
+	prices := g.NewEmptyStorage(lbl + "price")
+	directions := g.NewEmptyStorage(lbl + "direction")
+	prevIndexes := g.NewEmptyStorage(lbl + "prevIndex")
+	prevPrices := g.NewEmptyStorage(lbl + "prevPrice")
+	firstIndexes := g.NewEmptyStorage(lbl + "firstIndex")
+	firstPrices := g.NewEmptyStorage(lbl + "firstPrice")
+	added := g.NewEmptyStorage(lbl + "added")
+
+	pivotHigh := g.pivotHigh(c.Depth, c.Depth, g.high)
+	pivotLow := g.pivotLow(c.Depth, c.Depth, g.low)
+
+	var atr serie.Serie
+	if c.ATRLength > 0 {
+		atr = g.atr(c.ATRLength, lbl+"atr")
+	}
+
+	state := g.NewWrapper(func() float64 {
+		index := g.BarIndex() - g.BarFuncIndex()
+		last, price, direction := serie.NZ(indexes.G(1)), prices.G(1), serie.NZ(directions.G(1))
+		prev, prevPrice := serie.NZ(prevIndexes.G(1)), prevPrices.G(1)
+		first, firstPrice := serie.NZ(firstIndexes.G(1)), firstPrices.G(1)
+		count := 0.0
+
+		deviation := c.Deviation / 100 * math.Abs(price)
+		if atr != nil {
+			// the rma of the ATR needs every bar
+			atr.Get()
+			deviation = c.Deviation * atr.G(c.Depth)
+		}
+
+		// add offers the pivot to the zigzag, dir is 1 for a high and -1 for a low
+		add := func(value, dir float64) {
+			pivot := float64(index - c.Depth + 1)
+			switch {
+			case direction == 0:
+				last, price, direction = pivot, value, dir
+			case direction == dir:
+				if (value-price)*dir > 0 {
+					last, price = pivot, value
+				}
+			case math.Abs(value-price) >= deviation:
+				count++
+				first, firstPrice = prev, prevPrice
+				prev, prevPrice = last, price
+				last, price, direction = pivot, value, dir
+			}
+		}
+
+		if index-2*c.Depth >= 0 {
+			if high := g.high.G(c.Depth); !serie.NA(high) && pivotHigh.Get() == True {
+				add(high, 1)
+			}
+			if low := g.low.G(c.Depth); !serie.NA(low) && pivotLow.Get() == True {
+				add(low, -1)
+			}
+		}
+
+		*indexes.Set(0) = last
+		*prices.Set(0) = price
+		*directions.Set(0) = direction
+		*prevIndexes.Set(0) = prev
+		*prevPrices.Set(0) = prevPrice
+		*firstIndexes.Set(0) = first
+		*firstPrices.Set(0) = firstPrice
+		*added.Set(0) = count
+		return direction
+	}).Cache(BUILT_IN + lbl)
+
+	// of returns the value of storage once the state of the bar is computed
+	of := func(storage serie.Serie, isIndex bool) serie.Serie {
+		return g.NewWrapper(func() float64 {
+			if state.Get() == 0 {
+				return math.NaN()
+			}
+
+			value := storage.Get()
+			if isIndex {
+				if value < 1 {
+					return math.NaN()
+				}
+				return value - 1
+			}
+			return value
+		})
+	}
+
+	return ZigZag{
+		Price:      of(prices, false),
+		Index:      of(indexes, true),
+		Direction:  of(directions, false),
+		PrevPrice:  of(prevPrices, false),
+		PrevIndex:  of(prevIndexes, true),
+		firstPrice: of(firstPrices, false),
+		firstIndex: of(firstIndexes, true),
+		added:      of(added, false),
+		g:          g,
+	}
+}
+
+// bits of the events of a bar of MarketStructure
+const (
+	higherHigh = 1 << iota
+	lowerHigh
+	higherLow
+	lowerLow
+	bullishBOS
+	bearishBOS
+	bullishCHoCH
+	bearishCHoCH
+)
+
+// MarketStructure follows the swings (pivot highs and lows) of the price. Swings are labeled when they're confirmed,
+// rightBars after them; a close beyond the last swing high or low breaks it once: a break of structure (BOS) in the
+// direction of the trend, a change of character (CHoCH) against it
+type MarketStructure struct {
+	HigherHigh serie.Serie // True or False
+	LowerHigh  serie.Serie
+	HigherLow  serie.Serie
+	LowerLow   serie.Serie
+
+	BullishBOS   serie.Serie
+	BearishBOS   serie.Serie
+	BullishCHoCH serie.Serie
+	BearishCHoCH serie.Serie
+	Trend        serie.Serie // 1 after a bullish break, -1 after a bearish one, 0 before the first one
+
+	SwingHigh      serie.Serie // price of the last swing high, NaN before the first one
+	SwingHighIndex serie.Serie
+	SwingLow       serie.Serie
+	SwingLowIndex  serie.Serie
+
+	rightBars int
+	events    serie.Serie
+	g         *GoQuant
+}
+
+// Draw labels the swings confirmed on the current bar with annotations and draws the swings broken on it
+// up to the current bar, call it on every bar
+func (m MarketStructure) Draw(line LineF, config *LineConfig) {
+	g := m.g
+	events := int(m.events.Get())
+
+	c := LineConfig{}
+	if config != nil {
+		c = *config
+	}
+
+	labels := []struct {
+		bit  int
+		text string
+	}{{higherHigh, "HH"}, {lowerHigh, "LH"}, {higherLow, "HL"}, {lowerLow, "LL"}}
+
+	for _, l := range labels {
+		if events&l.bit == 0 {
+			continue
+		}
+
+		value := m.SwingHigh.Get()
+		if l.bit == higherLow || l.bit == lowerLow {
+			value = m.SwingLow.Get()
+		}
+
+		annotation := c
+		annotation.Shift = -m.rightBars
+		g.Annotate(l.text, value, &annotation)
+	}
+
+	breaks := []struct {
+		bit   int
+		text  string
+		price serie.Serie
+		index serie.Serie
+	}{
+		{bullishBOS, "BOS", m.SwingHigh, m.SwingHighIndex},
+		{bullishCHoCH, "CHoCH", m.SwingHigh, m.SwingHighIndex},
+		{bearishBOS, "BOS", m.SwingLow, m.SwingLowIndex},
+		{bearishCHoCH, "CHoCH", m.SwingLow, m.SwingLowIndex},
+	}
+
+	for _, b := range breaks {
+		if events&b.bit == 0 {
+			continue
+		}
+
+		price := b.price.Get()
+		p1 := Point{X: g.bars[int(b.index.Get())].Time, Y: price}
+		p2 := Point{X: g.bars[g.BarIndex()].Time, Y: price}
+		line(p1, p2, &c)
+
+		annotation := c
+		annotation.Shift = 0
+		g.Annotate(b.text, price, &annotation)
+	}
+}
+
+func (g *GoQuant) marketStructure(leftBars, rightBars int, label ...string) MarketStructure {
+	lbl := g.labelOf("structure", label)
+
+	// bar index + 1 of the swings, 0 before the first ones
+	highIndexes := g.NewEmptyStorage(lbl + "highIndex")
+	highs := g.NewEmptyStorage(lbl + "high")
+	lowIndexes := g.NewEmptyStorage(lbl + "lowIndex")
+	lows := g.NewEmptyStorage(lbl + "low")
+	broken := g.NewEmptyStorage(lbl + "broken") // 1 once the last swing high is broken, 2 the last swing low, 3 both
+	trends := g.NewEmptyStorage(lbl + "trend")
+
+	pivotHigh := g.pivotHigh(leftBars, rightBars, g.high)
+	pivotLow := g.pivotLow(leftBars, rightBars, g.low)
+
+	events := g.NewWrapper(func() float64 {
+		index := g.BarIndex() - g.BarFuncIndex()
+		highIndex, high := serie.NZ(highIndexes.G(1)), highs.G(1)
+		lowIndex, low := serie.NZ(lowIndexes.G(1)), lows.G(1)
+		isBroken, trend := int(serie.NZ(broken.G(1))), serie.NZ(trends.G(1))
+		events := 0
+
+		if index-leftBars-rightBars >= 0 {
+			if value := g.high.G(rightBars); !serie.NA(value) && pivotHigh.Get() == True {
+				if highIndex >= 1 {
+					if value > high {
+						events |= higherHigh
+					} else {
+						events |= lowerHigh
+					}
+				}
+				highIndex, high = float64(index-rightBars+1), value
+				isBroken &^= 1
+			}
+
+			if value := g.low.G(rightBars); !serie.NA(value) && pivotLow.Get() == True {
+				if lowIndex >= 1 {
+					if value > low {
+						events |= higherLow
+					} else {
+						events |= lowerLow
+					}
+				}
+				lowIndex, low = float64(index-rightBars+1), value
+				isBroken &^= 2
+			}
+		}
+
+		close := g.close.Get()
+		if highIndex >= 1 && isBroken&1 == 0 && close > high {
+			if trend == -1 {
+				events |= bullishCHoCH
+			} else {
+				events |= bullishBOS
+			}
+			trend = 1
+			isBroken |= 1
+		}
+		if lowIndex >= 1 && isBroken&2 == 0 && close < low {
+			if trend == 1 {
+				events |= bearishCHoCH
+			} else {
+				events |= bearishBOS
+			}
+			trend = -1
+			isBroken |= 2
+		}
+
+		*highIndexes.Set(0) = highIndex
+		*highs.Set(0) = high
+		*lowIndexes.Set(0) = lowIndex
+		*lows.Set(0) = low
+		*broken.Set(0) = float64(isBroken)
+		*trends.Set(0) = trend
+		return float64(events)
+	}).Cache(BUILT_IN + lbl)
+
+	flag := func(bit int) serie.Serie {
+		return g.NewWrapper(func() float64 {
+			if int(events.Get())&bit != 0 {
+				return True
+			}
+			return False
+		})
+	}
+
+	// of returns the value of storage once the events of the bar are computed
+	of := func(storage, indexes serie.Serie, isIndex bool) serie.Serie {
+		return g.NewWrapper(func() float64 {
+			events.Get()
+			index := indexes.Get()
+			switch {
+			case index < 1:
+				return math.NaN()
+			case isIndex:
+				return index - 1
+			}
+			return storage.Get()
+		})
+	}
+
+	trend := g.NewWrapper(func() float64 {
+		events.Get()
+		return trends.Get()
+	})
+
+	return MarketStructure{
+		HigherHigh:     flag(higherHigh),
+		LowerHigh:      flag(lowerHigh),
+		HigherLow:      flag(higherLow),
+		LowerLow:       flag(lowerLow),
+		BullishBOS:     flag(bullishBOS),
+		BearishBOS:     flag(bearishBOS),
+		BullishCHoCH:   flag(bullishCHoCH),
+		BearishCHoCH:   flag(bearishCHoCH),
+		Trend:          trend,
+		SwingHigh:      of(highs, highIndexes, false),
+		SwingHighIndex: of(highIndexes, highIndexes, true),
+		SwingLow:       of(lows, lowIndexes, false),
+		SwingLowIndex:  of(lowIndexes, lowIndexes, true),
+		rightBars:      rightBars,
+		events:         events,
+		g:              g,
+	}
+}
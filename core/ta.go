@@ -1,9 +1,7 @@
 package core
 
 import (
-	"fmt"
 	"math"
-	"runtime"
 
 	"github.com/Go-Quant/goquant/serie"
 )
@@ -98,33 +96,90 @@ func (g *GoQuant) pivotLow(leftBars int, righBars int, source ...serie.Serie) se
 	})
 }
 
-func (g *GoQuant) sma(src serie.Serie, length float64) serie.Serie {
+// rollingSum returns the sum of f(src) over the last length bars, skipping NaN values, and the number of NaN values.
+// The sum and the NaN count are kept in storages and updated from the previous bar in O(1); without a previous
+// state (first bar, or src read only on some bars) and every length bars the window is summed again,
+// so rounding errors don't accumulate
+func (g *GoQuant) rollingSum(src serie.Serie, length int, lbl string, f serie.Func) (float64, int) {
+	sums := g.NewEmptyStorage(lbl + "sum")
+	counts := g.NewEmptyStorage(lbl + "nans") // NaN count + 1, 0 if not computed
+
+	value := func(back int) float64 {
+		if f == nil {
+			return src.G(back)
+		}
+		return f(src.G(back))
+	}
+
+	index := g.BarIndex() - g.BarFuncIndex()
+	sum, count := sums.G(1), counts.G(1)
+
+	if count >= 1 && index%length != 0 {
+		if x := value(0); serie.NA(x) {
+			count++
+		} else {
+			sum += x
+		}
+
+		if x := value(length); serie.NA(x) {
+			count--
+		} else {
+			sum -= x
+		}
+	} else {
+		sum, count = 0, 1
+		for i := 0; i < length; i++ {
+			if x := value(i); serie.NA(x) {
+				count++
+			} else {
+				sum += x
+			}
+		}
+	}
+
+	*sums.Set(0) = sum
+	*counts.Set(0) = count
+	return sum, int(count) - 1
+}
+
+// sum is NaN while the window holds a NaN value
+func (g *GoQuant) sum(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("sum", label, length)
+
 	return serie.NewWrapper(g, func() float64 {
-		sum := 0.0
-		for i := 0; i < int(length); i++ {
-			sum += src.G(i)
+		sum, nans := g.rollingSum(src, int(length), lbl, nil)
+		if nans > 0 {
+			return math.NaN()
 		}
 
-		return sum / length
+		return sum
 	})
 }
-func (g *GoQuant) vwma(src serie.Serie, length float64) serie.Serie {
+
+func (g *GoQuant) sma(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("sma", label, length)
+
 	return serie.NewWrapper(g, func() float64 {
-		up := g.sma(src.Mul(g.volume), length)
-		down := g.sma(g.volume, length)
+		sum, nans := g.rollingSum(src, int(length), lbl, nil)
+		if nans > 0 {
+			return math.NaN()
+		}
 
-		return up.Div(down).Get()
+		return sum / length
 	})
 }
 
+func (g *GoQuant) vwma(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("vwma", label)
+
+	up := g.sma(src.Mul(g.volume), length, lbl+"up")
+	down := g.sma(g.volume, length, lbl+"down")
+
+	return up.Div(down)
+}
+
 func (g *GoQuant) ema(src serie.Serie, length float64, label ...string) serie.Serie {
-	lbl := ""
-	if len(label) > 0 {
-		lbl = label[0]
-	} else {
-		_, _, line, _ := runtime.Caller(1)
-		lbl = fmt.Sprint(line)
-	}
+	lbl := g.labelOf("ema", label, length)
 
 	return serie.NewWrapper(g, func() float64 {
 		sum := g.NewEmptyStorage(lbl)
@@ -147,39 +202,36 @@ func abs(v float64) float64 {
 	return math.Abs(v)
 }
 
-func (g *GoQuant) atr(length float64, label ...string) serie.Serie {
-	lbl := ""
-	if len(label) > 0 {
-		lbl = label[0]
-	} else {
-		_, _, line, _ := runtime.Caller(1)
-		lbl = fmt.Sprint(line)
-	}
-
+// trueRange is the greatest of high - low and the distances from the previous close to the high and the low. Without
+// a previous close it's high - low when handleNA is set, NaN otherwise, like Pine's ta.tr(handle_na)
+func (g *GoQuant) trueRange(handleNA bool) serie.Serie {
 	return serie.NewWrapper(g, func() float64 {
-		var trueRange serie.Serie
-
-		if serie.NA(g.high.G(1)) {
-			trueRange = g.high.Sub(g.low)
-		} else {
-			trueRange = g.Max(
-				g.Max(g.high.Sub(g.low), g.high.Sub(g.close.B(1).Custom(abs))),
-				g.low.Sub(g.close.B(1)).Custom(abs),
-			)
+		high, low, close1 := g.high.Get(), g.low.Get(), g.close.G(1)
+		if serie.NA(close1) {
+			if handleNA {
+				return high - low
+			}
+			return math.NaN()
 		}
 
+		return math.Max(high-low, math.Max(math.Abs(high-close1), math.Abs(low-close1)))
+	})
+}
+
+func (g *GoQuant) atr(length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("atr", label, length)
+
+	// the true range is NaN without a previous close, high - low when it's the whole window, so the first average
+	// is over length true ranges. It doesn't depend on the bar it's built on, as the running sum of rma requires
+	trueRange := g.trueRange(length == 1)
+
+	return serie.NewWrapper(g, func() float64 {
 		return g.rma(trueRange, length, lbl).Get()
 	}).Cache(BUILT_IN + lbl)
 }
 
 func (g *GoQuant) rma(src serie.Serie, length float64, label ...string) serie.Serie {
-	lbl := ""
-	if len(label) > 0 {
-		lbl = label[0]
-	} else {
-		_, _, line, _ := runtime.Caller(1)
-		lbl = fmt.Sprint(line)
-	}
+	lbl := g.labelOf("rma", label, length)
 
 	return serie.NewWrapper(g, func() float64 {
 		alpha := 1 / length
@@ -189,7 +241,7 @@ func (g *GoQuant) rma(src serie.Serie, length float64, label ...string) serie.Se
 		var sum0 float64
 
 		if serie.NA(sum1) {
-			sum0 = g.sma(src, length).Get()
+			sum0 = g.sma(src, length, lbl).Get()
 		} else {
 			sum0 = alpha*src.Get() + (1-alpha)*serie.NZ(sum1)
 		}
@@ -200,13 +252,7 @@ func (g *GoQuant) rma(src serie.Serie, length float64, label ...string) serie.Se
 }
 
 func (g *GoQuant) rsi(src serie.Serie, length float64, label ...string) serie.Serie {
-	lbl := ""
-	if len(label) > 0 {
-		lbl = label[0]
-	} else {
-		_, _, line, _ := runtime.Caller(1)
-		lbl = fmt.Sprint(line)
-	}
+	lbl := g.labelOf("rsi", label, length)
 
 	return serie.NewWrapper(g, func() float64 {
 		zero := g.NewEmptyStorage(lbl)
@@ -219,71 +265,3 @@ func (g *GoQuant) rsi(src serie.Serie, length float64, label ...string) serie.Se
 		return 100 - 100/(1+rs.Get())
 	}).Cache(BUILT_IN + lbl)
 }
-
-// returns True or False
-// only bullish divergences
-func (g *GoQuant) divergence(
-	rsiLen int,
-	rsiSource serie.Serie,
-	lbR int,
-	lbL int,
-	rangeUpper int,
-	rangeLower int,
-	label ...string) serie.Serie {
-
-	lbl := ""
-	if len(label) > 0 {
-		lbl = label[0]
-	} else {
-		_, _, line, _ := runtime.Caller(1)
-		lbl = fmt.Sprint(line)
-	}
-
-	inRange := func(cond func() bool) serie.Serie {
-		return g.NewWrapper(func() float64 {
-			bars := int(g.barsSince(cond).Get())
-			if rangeLower <= bars && bars <= rangeUpper {
-				return True
-			}
-
-			return False
-		})
-	}
-
-	return g.NewWrapper(func() float64 {
-		osc := g.rsi(rsiSource, float64(rsiLen), lbl)
-
-		// -----------
-		pl :=
-			g.pivotLow(lbL, lbR, osc)
-
-		// if the current rsi bar isn't a pivot low
-		if pl.Get() == False {
-			return False
-		}
-		// -----------
-
-		// -----------
-		// if the current rsi bar isn't higher low
-		oscHL :=
-			inRange(func() bool { return pl.G(1) == True }).Get() == True &&
-				osc.G(lbR) > g.valueWhen(osc.B(lbR), func() bool { return pl.Get() == True }, 1).Get()
-
-		if !oscHL {
-			return False
-		}
-		// -----------
-
-		// -----------
-		// if the current bar isn't lower low
-		priceLL :=
-			g.low.B(lbR).Get() < g.valueWhen(g.low.B(lbR), func() bool { return pl.Get() == True }, 1).Get()
-
-		if !priceLL {
-			return False
-		}
-		// -----------
-
-		return True
-	}).Cache(BUILT_IN + lbl)
-}
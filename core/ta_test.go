@@ -0,0 +1,181 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// testBars returns n bars around 50000, far from 0 so rounding shows, with the given gap bars
+func testBars(n int, gaps ...int) []serie.Bar {
+	bars := make([]serie.Bar, n)
+	for i := range bars {
+		close := 50000 + 1000*math.Sin(float64(i)/7) + 300*math.Cos(float64(i)*1.3)
+		open := close - 150*math.Sin(float64(i)*2.1)
+		bars[i] = serie.Bar{
+			Time:   float64(1700000000 + i*60),
+			Open:   open,
+			Close:  close,
+			High:   math.Max(open, close) + 40 + 30*math.Sin(float64(i)),
+			Low:    math.Min(open, close) - 40 - 30*math.Cos(float64(i)),
+			Volume: 100 + 50*math.Sin(float64(i)/3),
+		}
+	}
+
+	nan := math.NaN()
+	for _, i := range gaps {
+		bars[i] = serie.Bar{Time: bars[i].Time, Open: nan, High: nan, Low: nan, Close: nan, Volume: nan}
+	}
+
+	return bars
+}
+
+// naiveSum sums the window ending at i, NaN when it holds a NaN value or starts before the first bar
+func naiveSum(values []float64, i, length int) float64 {
+	if i+1 < length {
+		return math.NaN()
+	}
+
+	sum := 0.0
+	for j := i - length + 1; j <= i; j++ {
+		sum += values[j]
+	}
+
+	return sum
+}
+
+// naiveATR is Wilder's average of the true ranges, seeded with their mean on the first bar without a previous average
+func naiveATR(bars []serie.Bar, length int) []float64 {
+	trueRanges := make([]float64, len(bars))
+	for i, bar := range bars {
+		trueRanges[i] = math.NaN()
+		switch {
+		case i > 0 && !serie.NA(bars[i-1].Close):
+			close1 := bars[i-1].Close
+			trueRanges[i] = math.Max(math.Max(bar.High-bar.Low, math.Abs(bar.High-close1)), math.Abs(bar.Low-close1))
+		case length == 1:
+			trueRanges[i] = bar.High - bar.Low
+		}
+	}
+
+	result := make([]float64, len(bars))
+	for i := range bars {
+		if i == 0 || serie.NA(result[i-1]) {
+			result[i] = naiveSum(trueRanges, i, length) / float64(length)
+		} else {
+			result[i] = (trueRanges[i] + float64(length-1)*result[i-1]) / float64(length)
+		}
+	}
+
+	return result
+}
+
+func closeEnough(a, b float64) bool {
+	if serie.NA(a) || serie.NA(b) {
+		return serie.NA(a) && serie.NA(b)
+	}
+
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+func TestRollingSumMatchesNaiveSum(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		gaps   []int
+	}{
+		{"length 1", 1, nil},
+		{"length 3", 3, nil},
+		{"length 14", 14, nil},
+		{"length 14 with gaps", 14, []int{30, 31, 32, 90, 200}},
+		{"gap in the first window", 5, []int{2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bars := testBars(300, test.gaps...)
+			closes := make([]float64, len(bars))
+			for i, bar := range bars {
+				closes[i] = bar.Close
+			}
+
+			g := New()
+			g.AddBars(bars)
+			g.Logic(func(open, high, close, low, volume, time serie.Serie, ta TA, plot PlotF, line LineF, vline VLineF, hline HLineF) {
+				i := g.BarIndex()
+				want := naiveSum(closes, i, test.length)
+
+				if got := ta.Sum(close, float64(test.length)).Get(); !closeEnough(got, want) {
+					t.Errorf("bar %d: Sum = %v, want %v", i, got, want)
+				}
+				if got := ta.SMA(close, float64(test.length)).Get(); !closeEnough(got, want/float64(test.length)) {
+					t.Errorf("bar %d: SMA = %v, want %v", i, got, want/float64(test.length))
+				}
+			})
+		})
+	}
+}
+
+func TestATRMatchesNaiveATR(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		gaps   []int
+	}{
+		{"length 1", 1, []int{10, 50, 51}},
+		{"length 3", 3, []int{10, 50, 51}},
+		{"length 14", 14, nil},
+		{"length 14 with gaps", 14, []int{40, 41, 150}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bars := testBars(300, test.gaps...)
+			want := naiveATR(bars, test.length)
+
+			g := New()
+			g.AddBars(bars)
+			g.Logic(func(open, high, close, low, volume, time serie.Serie, ta TA, plot PlotF, line LineF, vline VLineF, hline HLineF) {
+				i := g.BarIndex()
+				if got := ta.ATR(float64(test.length)).Get(); !closeEnough(got, want[i]) {
+					t.Errorf("bar %d: ATR = %v, want %v", i, got, want[i])
+				}
+			})
+		})
+	}
+}
+
+func TestKeltnerAndATRShareTheTrueRange(t *testing.T) {
+	bars := testBars(100, 0, 20, 21)
+	want := naiveATR(bars, 1)
+
+	g := New()
+	g.AddBars(bars)
+	g.Logic(func(open, high, close, low, volume, time serie.Serie, ta TA, plot PlotF, line LineF, vline VLineF, hline HLineF) {
+		i := g.BarIndex()
+
+		// over 1 bar, the ATR and the width of the channels are the true range itself
+		atr := ta.ATR(1).Get()
+		keltner := ta.Keltner(close, 1, 2)
+		bands := ta.ATRBands(close, 1, 2)
+		if !closeEnough(atr, want[i]) {
+			t.Errorf("bar %d: ATR = %v, want %v", i, atr, want[i])
+		}
+
+		// without a previous close, it's NaN unless NA values are handled
+		strict := want[i]
+		if i == 0 || serie.NA(bars[i-1].Close) {
+			strict = math.NaN()
+		}
+		if got := g.trueRange(false).Get(); !closeEnough(got, strict) {
+			t.Errorf("bar %d: true range = %v, want %v", i, got, strict)
+		}
+		if got := keltner.Upper.Sub(keltner.Basis).Get() / 2; !closeEnough(got, atr) {
+			t.Errorf("bar %d: Keltner width = %v, want the ATR %v", i, got, atr)
+		}
+		if got := bands.Upper.Sub(bands.Basis).Get() / 2; !closeEnough(got, atr) {
+			t.Errorf("bar %d: ATR bands width = %v, want the ATR %v", i, got, atr)
+		}
+	})
+}
@@ -0,0 +1,229 @@
+package core
+
+import (
+	"math"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+type DMILines struct {
+	Plus  serie.Serie // +DI
+	Minus serie.Serie // -DI
+	ADX   serie.Serie
+}
+
+type SuperTrendLine struct {
+	Value     serie.Serie
+	Direction serie.Serie // -1 in an uptrend (Value below the price), 1 in a downtrend, like Pine
+}
+
+// IchimokuCloud spans are computed on the current bar, plot LeadA and LeadB with PlotConfig.Shift: Shift,
+// and Lagging with Shift: -Shift. The cloud over the current bar is LeadA.G(Shift) and LeadB.G(Shift)
+type IchimokuCloud struct {
+	Conversion serie.Serie // Tenkan-sen
+	Base       serie.Serie // Kijun-sen
+	LeadA      serie.Serie // Senkou span A
+	LeadB      serie.Serie // Senkou span B
+	Lagging    serie.Serie // Chikou span
+	Shift      int         // displacement - 1
+}
+
+func (g *GoQuant) dmi(diLength, adxSmoothing float64, label ...string) DMILines {
+	lbl := g.labelOf("dmi", label)
+
+	up := g.high.Sub(g.high.B(1))
+	down := g.low.B(1).Sub(g.low)
+
+	plusDM := g.NewWrapper(func() float64 {
+		u, d := up.Get(), down.Get()
+		if serie.NA(u) {
+			return math.NaN()
+		}
+		if u > d && u > 0 {
+			return u
+		}
+		return 0
+	})
+
+	minusDM := g.NewWrapper(func() float64 {
+		u, d := up.Get(), down.Get()
+		if serie.NA(d) {
+			return math.NaN()
+		}
+		if d > u && d > 0 {
+			return d
+		}
+		return 0
+	})
+
+	trueRange := g.atr(diLength, lbl+"atr")
+	plus := g.rma(plusDM, diLength, lbl+"plus").Mul(100.0).Div(trueRange)
+	minus := g.rma(minusDM, diLength, lbl+"minus").Mul(100.0).Div(trueRange)
+
+	dx := g.NewWrapper(func() float64 {
+		p, m := plus.Get(), minus.Get()
+		sum := p + m
+		if sum == 0 {
+			sum = 1
+		}
+		return math.Abs(p-m) / sum
+	})
+
+	return DMILines{Plus: plus, Minus: minus, ADX: g.rma(dx, adxSmoothing, lbl+"adx").Mul(100.0)}
+}
+
+// superTrend trails hl2 -/+ factor * ATR, the bands only move in the direction of the trend
+func (g *GoQuant) superTrend(factor, atrPeriod float64, label ...string) SuperTrendLine {
+	lbl := g.labelOf("supertrend", label)
+
+	atr := g.atr(atrPeriod, lbl+"atr")
+	upperBands := g.NewEmptyStorage(lbl + "upper")
+	lowerBands := g.NewEmptyStorage(lbl + "lower")
+	directions := g.NewEmptyStorage(lbl + "direction")
+
+	value := g.NewWrapper(func() float64 {
+		src := (g.high.Get() + g.low.Get()) / 2
+		upper := src + factor*atr.Get()
+		lower := src - factor*atr.Get()
+
+		prevUpper := serie.NZ(upperBands.G(1))
+		prevLower := serie.NZ(lowerBands.G(1))
+		close1 := g.close.G(1)
+
+		if !(lower > prevLower || close1 < prevLower) {
+			lower = prevLower
+		}
+		if !(upper < prevUpper || close1 > prevUpper) {
+			upper = prevUpper
+		}
+
+		close := g.close.Get()
+		direction := 1.0
+		if serie.NA(atr.G(1)) {
+			direction = 1
+		} else if directions.G(1) == 1 {
+			if close > upper {
+				direction = -1
+			}
+		} else if !(close < lower) {
+			direction = -1
+		}
+
+		*upperBands.Set(0) = upper
+		*lowerBands.Set(0) = lower
+		*directions.Set(0) = direction
+
+		if direction == -1 {
+			return lower
+		}
+		return upper
+	}).Cache(BUILT_IN + lbl)
+
+	direction := g.NewWrapper(func() float64 {
+		value.Get()
+		return directions.Get()
+	})
+
+	return SuperTrendLine{Value: value, Direction: direction}
+}
+
+// sar is the Parabolic SAR, the acceleration factor starts at start and grows by inc up to max on each new extreme
+func (g *GoQuant) sar(start, inc, max float64, label ...string) serie.Serie {
+	lbl := g.labelOf("sar", label)
+
+	results := g.NewEmptyStorage(lbl)
+	extremes := g.NewEmptyStorage(lbl + "extreme")
+	accelerations := g.NewEmptyStorage(lbl + "acceleration")
+	sides := g.NewEmptyStorage(lbl + "side") // 1 below the price, -1 above, 0 if not computed
+
+	return g.NewWrapper(func() float64 {
+		high, low := g.high.Get(), g.low.Get()
+		if serie.NA(high) || serie.NA(low) {
+			return math.NaN()
+		}
+
+		result, extreme, acceleration := results.G(1), extremes.G(1), accelerations.G(1)
+		isBelow := sides.G(1) == 1
+		isFirstTrendBar := false
+
+		// starts over on the first bar following one without state
+		if side := sides.G(1); serie.NA(side) || side == 0 {
+			close1 := g.close.G(1)
+			if serie.NA(close1) {
+				return math.NaN()
+			}
+
+			isBelow = g.close.Get() > close1
+			if isBelow {
+				extreme, result = high, g.low.G(1)
+			} else {
+				extreme, result = low, g.high.G(1)
+			}
+			isFirstTrendBar = true
+			acceleration = start
+		}
+
+		result += acceleration * (extreme - result)
+
+		if isBelow && result > low {
+			isFirstTrendBar, isBelow = true, false
+			result, extreme = math.Max(high, extreme), low
+			acceleration = start
+		} else if !isBelow && result < high {
+			isFirstTrendBar, isBelow = true, true
+			result, extreme = math.Min(low, extreme), high
+			acceleration = start
+		}
+
+		if !isFirstTrendBar {
+			if isBelow && high > extreme {
+				extreme = high
+				acceleration = math.Min(acceleration+inc, max)
+			} else if !isBelow && low < extreme {
+				extreme = low
+				acceleration = math.Min(acceleration+inc, max)
+			}
+		}
+
+		// never inside the range of the last two bars
+		for i := 1; i <= 2; i++ {
+			if isBelow && !serie.NA(g.low.G(i)) {
+				result = math.Min(result, g.low.G(i))
+			} else if !isBelow && !serie.NA(g.high.G(i)) {
+				result = math.Max(result, g.high.G(i))
+			}
+		}
+
+		side := -1.0
+		if isBelow {
+			side = 1
+		}
+
+		*results.Set(0) = result
+		*extremes.Set(0) = extreme
+		*accelerations.Set(0) = acceleration
+		*sides.Set(0) = side
+		return result
+	}).Cache(BUILT_IN + lbl)
+}
+
+// donchian returns the middle of the highest high and the lowest low of the last length bars
+func (g *GoQuant) donchian(length int, lbl string) serie.Serie {
+	return g.lowest(g.low, float64(length), lbl).Add(g.highest(g.high, float64(length), lbl)).Div(2.0)
+}
+
+func (g *GoQuant) ichimoku(conversionLength, baseLength, spanBLength, displacement int, label ...string) IchimokuCloud {
+	lbl := g.labelOf("ichimoku", label)
+
+	conversion := g.donchian(conversionLength, lbl+"conversion")
+	base := g.donchian(baseLength, lbl+"base")
+
+	return IchimokuCloud{
+		Conversion: conversion,
+		Base:       base,
+		LeadA:      conversion.Add(base).Div(2.0),
+		LeadB:      g.donchian(spanBLength, lbl+"leadB"),
+		Lagging:    g.close,
+		Shift:      displacement - 1,
+	}
+}
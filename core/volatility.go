@@ -0,0 +1,189 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+type BollingerBands struct {
+	Basis     serie.Serie // SMA of src
+	Upper     serie.Serie // Basis + mult * stdev
+	Lower     serie.Serie // Basis - mult * stdev
+	PercentB  serie.Serie // where src stands between the bands, 0 on Lower and 1 on Upper
+	Bandwidth serie.Serie // (Upper - Lower) / Basis
+}
+
+func (g *GoQuant) bollinger(src serie.Serie, length, mult float64, label ...string) BollingerBands {
+	lbl := g.labelOf("bb", label)
+
+	basis := g.sma(src, length, lbl+"basis")
+	dev := g.stdev(src, length, lbl+"dev").Mul(mult)
+	upper := basis.Add(dev)
+	lower := basis.Sub(dev)
+
+	return BollingerBands{
+		Basis:     basis,
+		Upper:     upper,
+		Lower:     lower,
+		PercentB:  src.Sub(lower).Div(upper.Sub(lower)),
+		Bandwidth: upper.Sub(lower).Div(basis),
+	}
+}
+
+// VolatilityKinds lists the estimators accepted by TA.HistoricalVolatility
+var VolatilityKinds = []string{"close", "parkinson", "garmanklass", "yangzhang"}
+
+type Channel struct {
+	Basis serie.Serie
+	Upper serie.Serie
+	Lower serie.Serie
+}
+
+type ChandelierExit struct {
+	Long      serie.Serie // stop of long positions, below the price
+	Short     serie.Serie // stop of short positions, above the price
+	Direction serie.Serie // 1 while the close stays over the last short stop, -1 once it falls under the last long stop
+}
+
+// keltner is the EMA of src -/+ mult times the EMA of the true range, like Pine's ta.kc
+func (g *GoQuant) keltner(src serie.Serie, length, mult float64, label ...string) Channel {
+	lbl := g.labelOf("keltner", label)
+
+	basis := g.ema(src, length, lbl+"basis")
+	width := g.ema(g.trueRange(true), length, lbl+"range").Mul(mult)
+
+	return Channel{Basis: basis, Upper: basis.Add(width), Lower: basis.Sub(width)}
+}
+
+// donchianChannel is the highest high and the lowest low of the last length bars, and the middle of them
+func (g *GoQuant) donchianChannel(length float64, label ...string) Channel {
+	lbl := g.labelOf("donchian", label)
+
+	upper := g.highest(g.high, length, lbl)
+	lower := g.lowest(g.low, length, lbl)
+
+	return Channel{Basis: upper.Add(lower).Div(2.0), Upper: upper, Lower: lower}
+}
+
+// atrBands are src -/+ mult times ATR(length)
+func (g *GoQuant) atrBands(src serie.Serie, length, mult float64, label ...string) Channel {
+	lbl := g.labelOf("atrbands", label)
+	width := g.atr(length, lbl).Mul(mult)
+
+	return Channel{Basis: src, Upper: src.Add(width), Lower: src.Sub(width)}
+}
+
+// chandelier hangs the long stop mult ATRs under the highest high of the last length bars and the short stop
+// over the lowest low. A stop only tightens while the previous close is on its side
+func (g *GoQuant) chandelier(length, mult float64, label ...string) ChandelierExit {
+	lbl := g.labelOf("chandelier", label)
+
+	atr := g.atr(length, lbl).Mul(mult)
+	highest := g.highest(g.high, length, lbl)
+	lowest := g.lowest(g.low, length, lbl)
+
+	longs := g.NewEmptyStorage(lbl + "long")
+	shorts := g.NewEmptyStorage(lbl + "short")
+	directions := g.NewEmptyStorage(lbl + "direction")
+
+	longStop := g.NewWrapper(func() float64 {
+		long := highest.Get() - atr.Get()
+		short := lowest.Get() + atr.Get()
+		prevLong, prevShort := longs.G(1), shorts.G(1)
+		if serie.NA(prevLong) {
+			prevLong = long
+		}
+		if serie.NA(prevShort) {
+			prevShort = short
+		}
+
+		close, close1 := g.close.Get(), g.close.G(1)
+		if close1 > prevLong {
+			long = math.Max(long, prevLong)
+		}
+		if close1 < prevShort {
+			short = math.Min(short, prevShort)
+		}
+
+		direction := directions.G(1)
+		switch {
+		case close > prevShort:
+			direction = 1
+		case close < prevLong:
+			direction = -1
+		case serie.NA(direction) || direction == 0:
+			direction = 1
+		}
+
+		*longs.Set(0) = long
+		*shorts.Set(0) = short
+		*directions.Set(0) = direction
+		return long
+	}).Cache(BUILT_IN + lbl)
+
+	shortStop := g.NewWrapper(func() float64 {
+		longStop.Get()
+		return shorts.Get()
+	})
+
+	direction := g.NewWrapper(func() float64 {
+		longStop.Get()
+		return directions.Get()
+	})
+
+	return ChandelierExit{Long: longStop, Short: shortStop, Direction: direction}
+}
+
+// historicalVolatility is the annualized volatility of the last length bars estimated by kind (one of
+// VolatilityKinds): the standard deviation of log returns ("close"), or from the range of the bars ("parkinson",
+// "garmanklass") and their overnight gaps ("yangzhang"). periodsPerYear is e.g. 365 for daily crypto bars, 252 for
+// daily stock bars. 0.2 is 20%
+func (g *GoQuant) historicalVolatility(kind string, length, periodsPerYear float64, label ...string) serie.Serie {
+	kind = strings.ToLower(kind)
+	lbl := g.labelOf("hv", label) + kind
+
+	ln := func(f func() float64) serie.Serie {
+		return g.NewWrapper(func() float64 { return math.Log(f()) })
+	}
+	annualize := func(variance float64) float64 {
+		return math.Sqrt(variance * periodsPerYear)
+	}
+
+	// log ratios of the bar
+	returns := ln(func() float64 { return g.close.Get() / g.close.G(1) })
+	highLow := ln(func() float64 { return g.high.Get() / g.low.Get() })
+	closeOpen := ln(func() float64 { return g.close.Get() / g.open.Get() })
+
+	var variance serie.Serie
+	switch kind {
+	case "close":
+		variance = g.variance(returns, length, lbl)
+	case "parkinson":
+		variance = g.sma(highLow.Mul(highLow), length, lbl).Div(4 * math.Ln2)
+	case "garmanklass":
+		terms := highLow.Mul(highLow).Mul(0.5).Sub(closeOpen.Mul(closeOpen).Mul(2*math.Ln2 - 1))
+		variance = g.sma(terms, length, lbl)
+	case "yangzhang":
+		overnight := ln(func() float64 { return g.open.Get() / g.close.G(1) })
+		rogersSatchell := g.NewWrapper(func() float64 {
+			bar := g.candle(0)
+			high := math.Log(bar.high/bar.close) * math.Log(bar.high/bar.open)
+			low := math.Log(bar.low/bar.close) * math.Log(bar.low/bar.open)
+			return high + low
+		})
+
+		// sample variances
+		bessel := length / (length - 1)
+		k := 0.34 / (1.34 + (length+1)/(length-1))
+		overnightVariance := g.variance(overnight, length, lbl+"overnight").Mul(bessel)
+		openCloseVariance := g.variance(closeOpen, length, lbl+"openclose").Mul(bessel)
+		variance = overnightVariance.Add(openCloseVariance.Mul(k)).Add(g.sma(rogersSatchell, length, lbl).Mul(1 - k))
+	default:
+		panic(fmt.Sprintf("unknown historical volatility %q, expected one of %v", kind, VolatilityKinds))
+	}
+
+	return variance.Custom(annualize)
+}
@@ -0,0 +1,237 @@
+package core
+
+import (
+	"math"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// VWAPConfig sets the periods VWAP starts over on, nil resets every UTC day
+type VWAPConfig struct {
+	Anchor   string                // timeframe of the periods: "1D" (default), "1W", "1M", "4h"...
+	Calendar *serie.ResampleConfig // timezone and session the periods are aligned to, e.g. a session VWAP
+}
+
+type VWAPLines struct {
+	VWAP  serie.Serie
+	Stdev serie.Serie // volume weighted standard deviation of src since the start of the period
+}
+
+// Upper returns the band mult standard deviations above VWAP
+func (v VWAPLines) Upper(mult float64) serie.Serie {
+	return v.VWAP.Add(v.Stdev.Mul(mult))
+}
+
+// Lower returns the band mult standard deviations below VWAP
+func (v VWAPLines) Lower(mult float64) serie.Serie {
+	return v.VWAP.Sub(v.Stdev.Mul(mult))
+}
+
+// cumulative returns the running total of src. Gap bars (NaN src) are NaN and leave the total unchanged
+func (g *GoQuant) cumulative(src serie.Serie, lbl string) serie.Serie {
+	totals := g.NewEmptyStorage(lbl)
+
+	return g.NewWrapper(func() float64 {
+		total := serie.NZ(totals.G(1))
+		value := src.Get()
+		if serie.NA(value) {
+			*totals.Set(0) = total
+			return math.NaN()
+		}
+
+		total += value
+		*totals.Set(0) = total
+		return total
+	}).Cache(BUILT_IN + lbl)
+}
+
+// moneyFlowVolume is the volume weighted by where the close stands in the range of the bar, from -volume to volume
+func (g *GoQuant) moneyFlowVolume() serie.Serie {
+	return g.NewWrapper(func() float64 {
+		high, low, close := g.high.Get(), g.low.Get(), g.close.Get()
+		if high == low {
+			return 0
+		}
+
+		return (2*close - low - high) / (high - low) * g.volume.Get()
+	})
+}
+
+// obv adds the volume of up bars and subtracts the one of down bars, compared to the last bar that isn't a gap
+func (g *GoQuant) obv(label ...string) serie.Serie {
+	lbl := g.labelOf("obv", label)
+	closes := g.NewEmptyStorage(lbl + "close") // last close, carried over gap bars
+
+	signedVolume := g.NewWrapper(func() float64 {
+		close, prevClose := g.close.Get(), closes.G(1)
+		if serie.NA(close) {
+			*closes.Set(0) = prevClose
+			return math.NaN()
+		}
+
+		*closes.Set(0) = close
+		switch {
+		case close > prevClose:
+			return serie.NZ(g.volume.Get())
+		case close < prevClose:
+			return -serie.NZ(g.volume.Get())
+		}
+		return 0
+	})
+
+	return g.cumulative(signedVolume, lbl)
+}
+
+// accDist is the Accumulation/Distribution line
+func (g *GoQuant) accDist(label ...string) serie.Serie {
+	return g.cumulative(g.moneyFlowVolume(), g.labelOf("accdist", label))
+}
+
+// cmf is the Chaikin Money Flow, gap bars are left out of the sums
+func (g *GoQuant) cmf(length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("cmf", label)
+	mfv := g.moneyFlowVolume()
+
+	return g.NewWrapper(func() float64 {
+		flow, _ := g.rollingSum(mfv, int(length), lbl+"flow", nil)
+		volume, _ := g.rollingSum(g.volume, int(length), lbl+"volume", nil)
+
+		index := g.BarIndex() - g.BarFuncIndex()
+		if index+1 < int(length) || serie.NA(g.close.Get()) {
+			return math.NaN()
+		}
+
+		return flow / volume
+	})
+}
+
+// mfi is the Money Flow Index of src (usually hlc3), the money flow of a bar is positive when src rose since the last
+// bar that isn't a gap, negative when it fell. Gap bars are left out of the sums
+func (g *GoQuant) mfi(src serie.Serie, length float64, label ...string) serie.Serie {
+	lbl := g.labelOf("mfi", label)
+	prices := g.NewEmptyStorage(lbl + "price") // last src, carried over gap bars
+	flows := g.NewEmptyStorage(lbl + "flow")
+
+	positive := func(x float64) float64 { return math.Max(x, 0) }
+	negative := func(x float64) float64 { return math.Max(-x, 0) }
+
+	return g.NewWrapper(func() float64 {
+		price, prevPrice := src.Get(), prices.G(1)
+		volume := serie.NZ(g.volume.Get())
+
+		flow := 0.0
+		switch {
+		case serie.NA(price):
+			price, flow = prevPrice, math.NaN()
+		case price > prevPrice:
+			flow = price * volume
+		case price < prevPrice:
+			flow = -price * volume
+		}
+
+		*prices.Set(0) = price
+		*flows.Set(0) = flow
+
+		// NaN flows count as 0
+		up, _ := g.rollingSum(flows, int(length), lbl+"up", positive)
+		down, _ := g.rollingSum(flows, int(length), lbl+"down", negative)
+
+		index := g.BarIndex() - g.BarFuncIndex()
+		if index < int(length) || serie.NA(flow) {
+			return math.NaN()
+		}
+
+		return 100 - 100/(1+up/down)
+	}).Cache(BUILT_IN + lbl)
+}
+
+// vwapFrom returns the VWAP of src since the last bar reset returned true on, NaN before it and on gap bars
+func (g *GoQuant) vwapFrom(src serie.Serie, lbl string, reset func() bool) VWAPLines {
+	volumes := g.NewEmptyStorage(lbl + "volume")
+	sums := g.NewEmptyStorage(lbl + "sum")       // volume * (src - base)
+	squares := g.NewEmptyStorage(lbl + "square") // volume * (src - base)²
+	bases := g.NewEmptyStorage(lbl + "base")     // first src of the period, prices far from 0 would lose the variance to rounding
+	stdevs := g.NewEmptyStorage(lbl + "stdev")
+	started := g.NewEmptyStorage(lbl + "started") // 1 once reset returned true
+
+	vwap := g.NewWrapper(func() float64 {
+		volume, sum, square, base := serie.NZ(volumes.G(1)), serie.NZ(sums.G(1)), serie.NZ(squares.G(1)), serie.NZ(bases.G(1))
+		if reset() {
+			volume, sum, square = 0, 0, 0
+			*started.Set(0) = 1
+		} else {
+			*started.Set(0) = started.G(1)
+		}
+
+		if started.Get() != 1 {
+			*stdevs.Set(0) = math.NaN()
+			return math.NaN()
+		}
+
+		price, v := src.Get(), g.volume.Get()
+		gap := serie.NA(price) || serie.NA(v)
+		if !gap {
+			if volume == 0 {
+				base = price
+			}
+
+			d := price - base
+			volume += v
+			sum += v * d
+			square += v * d * d
+		}
+
+		*volumes.Set(0) = volume
+		*sums.Set(0) = sum
+		*squares.Set(0) = square
+		*bases.Set(0) = base
+
+		if gap || volume == 0 {
+			*stdevs.Set(0) = math.NaN()
+			return math.NaN()
+		}
+
+		mean := sum / volume
+		*stdevs.Set(0) = math.Sqrt(math.Max(square/volume-mean*mean, 0))
+		return base + mean
+	}).Cache(BUILT_IN + lbl)
+
+	stdev := g.NewWrapper(func() float64 {
+		vwap.Get()
+		return stdevs.Get()
+	})
+
+	return VWAPLines{VWAP: vwap, Stdev: stdev}
+}
+
+// vwap starts over on each period of config, e.g. &VWAPConfig{Anchor: "1W"}
+func (g *GoQuant) vwap(src serie.Serie, config *VWAPConfig, label ...string) VWAPLines {
+	lbl := g.labelOf("vwap", label)
+
+	if config == nil {
+		config = &VWAPConfig{}
+	}
+	anchor := config.Anchor
+	if anchor == "" {
+		anchor = "1D"
+	}
+
+	tf, err := serie.ParseTimeframe(anchor)
+	if err != nil {
+		panic(err)
+	}
+
+	periods := g.NewEmptyStorage(lbl + "period")
+	reset := func() bool {
+		start := serie.BucketStart(g.time.Get(), tf, config.Calendar)
+		*periods.Set(0) = start
+		return start != periods.G(1)
+	}
+
+	return g.vwapFrom(src, lbl, reset)
+}
+
+// anchoredVWAP starts over on each bar anchor returns true on, e.g. an entry or a pivot
+func (g *GoQuant) anchoredVWAP(src serie.Serie, anchor func() bool, label ...string) VWAPLines {
+	return g.vwapFrom(src, g.labelOf("avwap", label), anchor)
+}
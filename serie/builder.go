@@ -0,0 +1,153 @@
+package serie
+
+import (
+	"fmt"
+	"math"
+)
+
+type Trade struct {
+	Time  float64 // seconds
+	Price float64
+	Size  float64
+}
+
+type BarType int
+
+const (
+	TimeBars   BarType = iota // a bar per timeframe bucket
+	TickBars                  // a bar per Threshold trades
+	VolumeBars                // a bar per Threshold traded size
+	DollarBars                // a bar per Threshold traded value (price * size)
+)
+
+type BarBuilderConfig struct {
+	Type      BarType
+	Timeframe string          // time bars, e.g. "1m"
+	Calendar  *ResampleConfig // time bars, timezone and session of the buckets
+	Threshold float64         // tick, volume and dollar bars
+}
+
+// BarBuilder builds bars from a stream of trades and calls onBar with each completed bar.
+// Time bars skipping buckets without trades emit NaN bars for them, like fillBarGaps
+type BarBuilder struct {
+	config    BarBuilderConfig
+	timeframe Timeframe
+	onBar     func(bar Bar)
+
+	forming    Bar
+	hasForming bool
+	amount     float64 // ticks, volume or dollars of the forming bar
+	last       float64 // start of the last time bar completed, NaN before the first
+}
+
+func NewBarBuilder(config BarBuilderConfig, onBar func(bar Bar)) (*BarBuilder, error) {
+	b := &BarBuilder{config: config, onBar: onBar, last: math.NaN()}
+
+	if config.Type == TimeBars {
+		tf, err := ParseTimeframe(config.Timeframe)
+		if err != nil {
+			return nil, err
+		}
+		b.timeframe = tf
+	} else if config.Threshold <= 0 {
+		return nil, fmt.Errorf("bar builder needs a positive threshold")
+	}
+
+	return b, nil
+}
+
+// Forming returns the bar being built, false if no trade arrived since the last completed bar
+func (b *BarBuilder) Forming() (Bar, bool) {
+	return b.forming, b.hasForming
+}
+
+// Add updates the forming bar with trade, completing bars as needed.
+// Trades must be chronological, late trades of time bars are merged into the forming bar, or dropped when their bar
+// was completed by Advance
+func (b *BarBuilder) Add(trade Trade) {
+	if b.config.Type == TimeBars {
+		if !InSession(trade.Time, b.config.Calendar) {
+			return
+		}
+
+		start := BucketStart(trade.Time, b.timeframe, b.config.Calendar)
+		if b.hasForming && start > b.forming.Time {
+			b.emit()
+		}
+		if !b.hasForming && !NA(b.last) {
+			if start <= b.last {
+				return
+			}
+			b.fillGaps(start)
+		}
+
+		b.update(trade, start)
+		return
+	}
+
+	b.update(trade, trade.Time)
+
+	switch b.config.Type {
+	case TickBars:
+		b.amount++
+	case VolumeBars:
+		b.amount += trade.Size
+	case DollarBars:
+		b.amount += trade.Price * trade.Size
+	}
+
+	if b.amount >= b.config.Threshold {
+		b.emit()
+	}
+}
+
+// Advance completes the forming time bar once now (seconds) is past its end, for quiet markets
+func (b *BarBuilder) Advance(now float64) {
+	if b.config.Type != TimeBars || !b.hasForming {
+		return
+	}
+
+	if now >= BucketEnd(b.forming.Time, b.timeframe, b.config.Calendar) {
+		b.emit()
+	}
+}
+
+func (b *BarBuilder) update(trade Trade, start float64) {
+	if !b.hasForming {
+		b.forming = Bar{Time: start, Open: trade.Price, High: trade.Price, Low: trade.Price, Close: trade.Price, Volume: trade.Size}
+		b.hasForming = true
+		return
+	}
+
+	b.forming.High = math.Max(b.forming.High, trade.Price)
+	b.forming.Low = math.Min(b.forming.Low, trade.Price)
+	b.forming.Close = trade.Price
+	b.forming.Volume += trade.Size
+}
+
+func (b *BarBuilder) emit() {
+	bar := b.forming
+	b.forming, b.hasForming, b.amount = Bar{}, false, 0
+	b.last = bar.Time
+	b.onBar(bar)
+}
+
+// fillGaps completes the empty buckets between the last time bar and the bucket at start with NaN bars
+func (b *BarBuilder) fillGaps(start float64) {
+	step := 60.0 * 60
+	if unit, intraday := intradayUnits[b.timeframe.Unit]; intraday {
+		step = math.Min(step, float64(b.timeframe.N)*unit)
+	}
+
+	NaN := math.NaN()
+	for t := b.last + step; t < start; t += step {
+		if !InSession(t, b.config.Calendar) {
+			continue
+		}
+
+		if gap := BucketStart(t, b.timeframe, b.config.Calendar); gap > b.last && gap < start {
+			b.last = gap
+			b.onBar(Bar{Time: gap, Open: NaN, High: NaN, Low: NaN, Close: NaN, Volume: NaN})
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package serie
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// event is a trade added to the builder, or a call to Advance at its time
+type event struct {
+	Trade
+	advance bool
+}
+
+func TestBarBuilder(t *testing.T) {
+	NaN := math.NaN()
+	open := at(t, "2024-01-02 10:00", nil)
+	trade := func(seconds, price, size float64) event {
+		return event{Trade: Trade{Time: open + seconds, Price: price, Size: size}}
+	}
+	advance := func(seconds float64) event {
+		return event{Trade: Trade{Time: open + seconds}, advance: true}
+	}
+	gap := func(seconds float64) Bar {
+		return Bar{Time: open + seconds, Open: NaN, High: NaN, Low: NaN, Close: NaN, Volume: NaN}
+	}
+	minute := BarBuilderConfig{Type: TimeBars, Timeframe: "1m"}
+
+	tests := []struct {
+		name    string
+		config  BarBuilderConfig
+		events  []event
+		want    []Bar
+		forming *Bar // nil for none
+	}{
+		{
+			"time bars",
+			minute,
+			[]event{trade(5, 100, 1), trade(30, 102, 2), trade(40, 99, 1), trade(70, 101, 1)},
+			[]Bar{{Time: open, Open: 100, High: 102, Low: 99, Close: 99, Volume: 4}},
+			&Bar{Time: open + 60, Open: 101, High: 101, Low: 101, Close: 101, Volume: 1},
+		},
+		{
+			"empty buckets emit NaN bars",
+			minute,
+			[]event{trade(5, 100, 1), trade(250, 99, 1)},
+			[]Bar{{Time: open, Open: 100, High: 100, Low: 100, Close: 100, Volume: 1}, gap(60), gap(120), gap(180)},
+			&Bar{Time: open + 240, Open: 99, High: 99, Low: 99, Close: 99, Volume: 1},
+		},
+		{
+			"advance completes the bar once past its end",
+			minute,
+			[]event{trade(5, 100, 1), advance(59), advance(60)},
+			[]Bar{{Time: open, Open: 100, High: 100, Low: 100, Close: 100, Volume: 1}},
+			nil,
+		},
+		{
+			"empty buckets after advance emit NaN bars",
+			minute,
+			[]event{trade(5, 100, 1), advance(60), trade(190, 99, 1)},
+			[]Bar{{Time: open, Open: 100, High: 100, Low: 100, Close: 100, Volume: 1}, gap(60), gap(120)},
+			&Bar{Time: open + 180, Open: 99, High: 99, Low: 99, Close: 99, Volume: 1},
+		},
+		{
+			"next bucket after advance",
+			minute,
+			[]event{trade(5, 100, 1), advance(60), trade(65, 99, 1)},
+			[]Bar{{Time: open, Open: 100, High: 100, Low: 100, Close: 100, Volume: 1}},
+			&Bar{Time: open + 60, Open: 99, High: 99, Low: 99, Close: 99, Volume: 1},
+		},
+		{
+			"late trade merged into the forming bar",
+			minute,
+			[]event{trade(65, 100, 1), trade(50, 98, 1)},
+			nil,
+			&Bar{Time: open + 60, Open: 100, High: 100, Low: 98, Close: 98, Volume: 2},
+		},
+		{
+			"late trade of a completed bar dropped",
+			minute,
+			[]event{trade(5, 100, 1), advance(60), trade(50, 98, 1)},
+			[]Bar{{Time: open, Open: 100, High: 100, Low: 100, Close: 100, Volume: 1}},
+			nil,
+		},
+		{
+			"no NaN bars outside the session",
+			BarBuilderConfig{Type: TimeBars, Timeframe: "1h", Calendar: &ResampleConfig{SessionStart: 9*time.Hour + 30*time.Minute, SessionEnd: 16 * time.Hour}},
+			// 15:45, 16:30 outside the session, and 09:45 the next day
+			[]event{trade(5*3600+45*60, 100, 1), trade(6*3600+30*60, 101, 1), trade(23*3600+45*60, 102, 1)},
+			[]Bar{{Time: open + 5*3600 + 30*60, Open: 100, High: 100, Low: 100, Close: 100, Volume: 1}},
+			&Bar{Time: open + 23*3600 + 30*60, Open: 102, High: 102, Low: 102, Close: 102, Volume: 1},
+		},
+		{
+			"tick bars",
+			BarBuilderConfig{Type: TickBars, Threshold: 2},
+			[]event{trade(1, 100, 1), trade(2, 101, 5), trade(3, 99, 1), trade(4, 98, 1), trade(5, 97, 1)},
+			[]Bar{
+				{Time: open + 1, Open: 100, High: 101, Low: 100, Close: 101, Volume: 6},
+				{Time: open + 3, Open: 99, High: 99, Low: 98, Close: 98, Volume: 2},
+			},
+			&Bar{Time: open + 5, Open: 97, High: 97, Low: 97, Close: 97, Volume: 1},
+		},
+		{
+			"volume bars",
+			BarBuilderConfig{Type: VolumeBars, Threshold: 5},
+			[]event{trade(1, 100, 2), trade(2, 101, 2), trade(3, 99, 2), trade(4, 98, 1), trade(5, 97, 4)},
+			[]Bar{
+				{Time: open + 1, Open: 100, High: 101, Low: 99, Close: 99, Volume: 6},
+				{Time: open + 4, Open: 98, High: 98, Low: 97, Close: 97, Volume: 5},
+			},
+			nil,
+		},
+		{
+			"dollar bars",
+			BarBuilderConfig{Type: DollarBars, Threshold: 1000},
+			[]event{trade(1, 100, 4), trade(2, 100, 7), trade(3, 200, 4)},
+			[]Bar{{Time: open + 1, Open: 100, High: 100, Low: 100, Close: 100, Volume: 11}},
+			&Bar{Time: open + 3, Open: 200, High: 200, Low: 200, Close: 200, Volume: 4},
+		},
+		{
+			"advance ignored by tick bars",
+			BarBuilderConfig{Type: TickBars, Threshold: 2},
+			[]event{trade(1, 100, 1), advance(3600)},
+			nil,
+			&Bar{Time: open + 1, Open: 100, High: 100, Low: 100, Close: 100, Volume: 1},
+		},
+	}
+
+	same := func(a, b Bar) bool {
+		for _, v := range [][2]float64{{a.Time, b.Time}, {a.Open, b.Open}, {a.High, b.High}, {a.Low, b.Low}, {a.Close, b.Close}, {a.Volume, b.Volume}} {
+			if v[0] != v[1] && !(NA(v[0]) && NA(v[1])) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []Bar
+			b, err := NewBarBuilder(test.config, func(bar Bar) { got = append(got, bar) })
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, e := range test.events {
+				if e.advance {
+					b.Advance(e.Time)
+				} else {
+					b.Add(e.Trade)
+				}
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("%d bars, want %d: %+v", len(got), len(test.want), got)
+			}
+			for i, want := range test.want {
+				if !same(got[i], want) {
+					t.Errorf("bar %d = %+v, want %+v", i, got[i], want)
+				}
+			}
+
+			forming, ok := b.Forming()
+			switch {
+			case test.forming == nil && ok:
+				t.Errorf("forming %+v, want none", forming)
+			case test.forming != nil && (!ok || !same(forming, *test.forming)):
+				t.Errorf("forming %+v (%v), want %+v", forming, ok, *test.forming)
+			}
+		})
+	}
+}
+
+func TestNewBarBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config BarBuilderConfig
+	}{
+		{"invalid timeframe", BarBuilderConfig{Type: TimeBars, Timeframe: "5x"}},
+		{"no threshold", BarBuilderConfig{Type: VolumeBars}},
+		{"negative threshold", BarBuilderConfig{Type: TickBars, Threshold: -1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewBarBuilder(test.config, func(Bar) {}); err == nil {
+				t.Error("no error")
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package serie
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVConfig describes how CSV columns map to bars, a nil config uses the defaults
+type CSVConfig struct {
+	// Columns maps a header (or a zero based column index when NoHeader is set) to a Bar field:
+	// Open, High, Low, Close, Volume, Time, or Date when date and time of day are in separate columns.
+	// Headers are matched case-insensitively, nil uses csvFieldMap
+	Columns   map[string]string
+	Delimiter rune // default ','
+	NoHeader  bool
+
+	// DateLayout is the time.Parse layout of the Time column, or of "<Date> <Time>" when there is a Date column.
+	// Empty accepts epoch timestamps (s, ms, µs or ns) and ISO dates
+	DateLayout string
+	Location   *time.Location // timezone of dates without offset, default UTC
+}
+
+var csvFieldMap = map[string]string{
+	"close":     "Close",
+	"open":      "Open",
+	"high":      "High",
+	"low":       "Low",
+	"volume":    "Volume",
+	"vol":       "Volume",
+	"tickvol":   "Volume",
+	"time":      "Time",
+	"timestamp": "Time",
+	"ts":        "Time",
+	"datetime":  "Time",
+	"open time": "Time",
+	"date":      "Date",
+}
+
+var isoLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006.01.02 15:04:05",
+	"2006.01.02 15:04",
+	"2006.01.02",
+}
+
+// normalizeHeader makes "<DATE>", " Date" and "date" equal
+func normalizeHeader(header string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(header), "<>"))
+}
+
+// ProcessBarsFromCSV reads bars from CSV data, then normalizes, orders and fills them like ProcessBarsFromJsonString
+func ProcessBarsFromCSV(reader io.Reader, config *CSVConfig) ([]Bar, error) {
+	if config == nil {
+		config = &CSVConfig{}
+	}
+
+	location := config.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	if config.Delimiter != 0 {
+		r.Comma = config.Delimiter
+	}
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV input: %v", err)
+	}
+
+	columns, err := csvColumns(records, config)
+	if err != nil {
+		return nil, err
+	}
+	if !config.NoHeader && len(records) > 0 {
+		records = records[1:]
+	}
+
+	cell := func(record []string, field string) (string, bool) {
+		i, exists := columns[field]
+		if !exists || i >= len(record) {
+			return "", false
+		}
+		return strings.TrimSpace(record[i]), true
+	}
+
+	// in column order, so a short row reports its first missing field
+	fields := []string{"Open", "High", "Low", "Close", "Volume"}
+	sort.SliceStable(fields, func(a, b int) bool { return columns[fields[a]] < columns[fields[b]] })
+
+	var bars []Bar
+	for line, record := range records {
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		bar := Bar{}
+		values := map[string]*float64{"Open": &bar.Open, "High": &bar.High, "Low": &bar.Low, "Close": &bar.Close, "Volume": &bar.Volume}
+		for _, field := range fields {
+			text, exists := cell(record, field)
+			// Volume is optional
+			if !exists && field == "Volume" {
+				continue
+			}
+			if !exists {
+				return nil, fmt.Errorf("missing %s on row %d", field, line+1)
+			}
+			if *values[field], err = parseCSVFloat(text); err != nil {
+				return nil, fmt.Errorf("error parsing %s on row %d: %v", field, line+1, err)
+			}
+		}
+
+		text, _ := cell(record, "Time")
+		if date, exists := cell(record, "Date"); exists {
+			text = strings.TrimSpace(date + " " + text)
+		}
+
+		if bar.Time, err = parseCSVTime(text, config.DateLayout, location); err != nil {
+			return nil, fmt.Errorf("error parsing time on row %d: %v", line+1, err)
+		}
+
+		bars = append(bars, bar)
+	}
+
+	return prepareBars(bars)
+}
+
+func ProcessBarsFromCSVPath(path string, config *CSVConfig) ([]Bar, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file %s: %v", path, err)
+	}
+	defer file.Close()
+
+	bars, err := ProcessBarsFromCSV(file, config)
+	if err != nil {
+		return nil, fmt.Errorf("error processing CSV data: %v", err)
+	}
+
+	return bars, nil
+}
+
+// csvColumns returns the column index of each Bar field
+func csvColumns(records [][]string, config *CSVConfig) (map[string]int, error) {
+	columns := make(map[string]int)
+
+	if config.NoHeader {
+		for key, field := range config.Columns {
+			i, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("column %q must be an index when the CSV has no header", key)
+			}
+			columns[field] = i
+		}
+	} else if len(records) > 0 {
+		mapping := csvFieldMap
+		if config.Columns != nil {
+			mapping = make(map[string]string)
+			for header, field := range config.Columns {
+				mapping[normalizeHeader(header)] = field
+			}
+		}
+
+		for i, header := range records[0] {
+			if field, exists := mapping[normalizeHeader(header)]; exists {
+				columns[field] = i
+			}
+		}
+	}
+
+	if _, hasTime := columns["Time"]; !hasTime {
+		if _, hasDate := columns["Date"]; !hasDate {
+			return nil, fmt.Errorf("CSV has no Time or Date column")
+		}
+	}
+	for _, field := range []string{"Open", "High", "Low", "Close"} {
+		if _, exists := columns[field]; !exists {
+			return nil, fmt.Errorf("CSV has no %s column", field)
+		}
+	}
+
+	return columns, nil
+}
+
+func parseCSVFloat(text string) (float64, error) {
+	switch strings.ToLower(text) {
+	case "", "null", "nan", "n/a":
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(text, 64)
+}
+
+// parseCSVTime returns the timestamp in seconds, epoch values in ms, µs or ns are normalized later by prepareBars
+func parseCSVTime(text string, layout string, location *time.Location) (float64, error) {
+	if layout != "" {
+		t, err := time.ParseInLocation(layout, text, location)
+		if err != nil {
+			return 0, err
+		}
+		return float64(t.Unix()), nil
+	}
+
+	if value, err := strconv.ParseFloat(text, 64); err == nil {
+		return value, nil
+	}
+
+	for _, layout := range isoLayouts {
+		if t, err := time.ParseInLocation(layout, text, location); err == nil {
+			return float64(t.Unix()), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown date format %q, set CSVConfig.DateLayout", text)
+}
@@ -0,0 +1,151 @@
+package serie
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// at returns the timestamp of "2006-01-02 15:04" in loc, UTC if nil
+func at(t *testing.T, value string, loc *time.Location) float64 {
+	t.Helper()
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	tm, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return float64(tm.Unix())
+}
+
+func TestProcessBarsFromCSV(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	day1, day2 := at(t, "2024-01-02 00:00", nil), at(t, "2024-01-03 00:00", nil)
+	both := func(time1, time2 float64) []Bar {
+		return []Bar{
+			{Time: time1, Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10},
+			{Time: time2, Open: 1.5, High: 3, Low: 1, Close: 2.5, Volume: 20},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		csv    string
+		config *CSVConfig
+		want   []Bar
+	}{
+		{
+			"default headers",
+			"Date,Open,High,Low,Close,Volume\n2024-01-02,1,2,0.5,1.5,10\n2024-01-03,1.5,3,1,2.5,20\n",
+			nil,
+			both(day1, day2),
+		},
+		{
+			"headers in brackets, date and time columns",
+			"<DATE>\t<TIME>\t<OPEN>\t<HIGH>\t<LOW>\t<CLOSE>\t<TICKVOL>\n" +
+				"2024.01.02\t09:30:00\t1\t2\t0.5\t1.5\t10\n2024.01.02\t09:31:00\t1.5\t3\t1\t2.5\t20\n",
+			&CSVConfig{Delimiter: '\t'},
+			both(at(t, "2024-01-02 09:30", nil), at(t, "2024-01-02 09:31", nil)),
+		},
+		{
+			"custom columns",
+			"t;o;h;l;c;v\n1704153600;1;2;0.5;1.5;10\n1704240000;1.5;3;1;2.5;20\n",
+			&CSVConfig{Delimiter: ';', Columns: map[string]string{"T": "Time", "o": "Open", "h": "High", "l": "Low", "c": "Close", "v": "Volume"}},
+			both(day1, day2),
+		},
+		{
+			"no header",
+			"1704153600,1,2,0.5,1.5,10\n1704240000,1.5,3,1,2.5,20\n",
+			&CSVConfig{NoHeader: true, Columns: map[string]string{"0": "Time", "1": "Open", "2": "High", "3": "Low", "4": "Close", "5": "Volume"}},
+			both(day1, day2),
+		},
+		{
+			"milliseconds",
+			"open time,open,high,low,close,volume\n1704153600000,1,2,0.5,1.5,10\n1704240000000,1.5,3,1,2.5,20\n",
+			nil,
+			both(1704153600, 1704240000),
+		},
+		{
+			"microseconds",
+			"ts,open,high,low,close,volume\n1704153600000000,1,2,0.5,1.5,10\n1704240000000000,1.5,3,1,2.5,20\n",
+			nil,
+			both(day1, day2),
+		},
+		{
+			"nanoseconds",
+			"timestamp,open,high,low,close,volume\n1704153600000000000,1,2,0.5,1.5,10\n1704240000000000000,1.5,3,1,2.5,20\n",
+			nil,
+			both(day1, day2),
+		},
+		{
+			"ISO dates with an offset",
+			"time,open,high,low,close,volume\n2024-01-02T01:00:00+01:00,1,2,0.5,1.5,10\n2024-01-03T01:00:00+01:00,1.5,3,1,2.5,20\n",
+			nil,
+			both(day1, day2),
+		},
+		{
+			"date layout in the location",
+			"datetime,open,high,low,close,volume\n02/01/2024 19:00,1,2,0.5,1.5,10\n03/01/2024 19:00,1.5,3,1,2.5,20\n",
+			&CSVConfig{DateLayout: "02/01/2006 15:04", Location: est},
+			both(at(t, "2024-01-03 00:00", nil), at(t, "2024-01-04 00:00", nil)),
+		},
+		{
+			"newest first",
+			"date,open,high,low,close,volume\n2024-01-03,1.5,3,1,2.5,20\n2024-01-02,1,2,0.5,1.5,10\n",
+			nil,
+			both(day1, day2),
+		},
+		{
+			"no volume",
+			"date,open,high,low,close\n2024-01-02,1,2,0.5,1.5\n",
+			nil,
+			[]Bar{{Time: day1, Open: 1, High: 2, Low: 0.5, Close: 1.5}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ProcessBarsFromCSV(strings.NewReader(test.csv), test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("%d bars, want %d: %+v", len(got), len(test.want), got)
+			}
+			for i, want := range test.want {
+				if got[i] != want {
+					t.Errorf("bar %d = %+v, want %+v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessBarsFromCSVErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		csv    string
+		config *CSVConfig
+		want   string
+	}{
+		{"no time column", "open,high,low,close\n1,2,0.5,1.5\n", nil, "no Time or Date column"},
+		{"no close column", "date,open,high,low\n2024-01-02,1,2,0.5\n", nil, "no Close column"},
+		{"short row", "date,open,high,low,close\n2024-01-02,1,2,0.5,1.5\n2024-01-03,1,2\n", nil, "missing Low on row 2"},
+		{"short row in another column order", "date,close,open,high,low\n2024-01-02,1.5,1,2,0.5\n2024-01-03,1,2\n", nil, "missing High on row 2"},
+		{"invalid number", "date,open,high,low,close\n2024-01-02,1,2,x,1.5\n", nil, "error parsing Low on row 1"},
+		{"unknown date format", "date,open,high,low,close\nJan 2 2024,1,2,0.5,1.5\n", nil, "unknown date format"},
+		{"header without NoHeader", "", &CSVConfig{NoHeader: true, Columns: map[string]string{"date": "Time"}}, "must be an index"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ProcessBarsFromCSV(strings.NewReader(test.csv), test.config)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error %v, want one containing %q", err, test.want)
+			}
+		})
+	}
+}
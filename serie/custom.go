@@ -3,11 +3,8 @@ package serie
 import (
 	"fmt"
 	"math"
-	"runtime"
 )
 
-var cache = make(map[string]map[int]float64)
-
 type CustomSerieWrapper struct {
 	f          *func() float64
 	operations *[][2]any // each operation will be a [operationType, factor] pair
@@ -55,8 +52,7 @@ func (c CustomSerieWrapper) Cache(label ...string) Serie {
 	if len(label) > 0 {
 		lbl = label[0]
 	} else {
-		_, _, line, _ := runtime.Caller(1)
-		lbl = fmt.Sprint(line)
+		lbl = c.GoQuant.CallSite(1)
 	}
 
 	c.cacheKey = lbl
@@ -122,7 +118,7 @@ func (c CustomSerieWrapper) Get() float64 {
 	index := c.GoQuant.BarIndex() - c.GoQuant.BarFuncIndex() // - c.backOffset
 
 	if c.cacheKey != "" {
-		if result, exists := cache[c.cacheKey][index]; exists {
+		if result, exists := c.GoQuant.GetCache(c.cacheKey, index); exists {
 			//fmt.Println("from cache", c.cacheKey)
 			return result
 		}
@@ -174,10 +170,7 @@ func (c CustomSerieWrapper) Get() float64 {
 	}
 
 	if c.cacheKey != "" {
-		if cache[c.cacheKey] == nil {
-			cache[c.cacheKey] = make(map[int]float64) // Initialize the inner map
-		}
-		cache[c.cacheKey][index] = result
+		c.GoQuant.SetCache(c.cacheKey, index, result)
 	}
 	return result
 }
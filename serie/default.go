@@ -50,6 +50,9 @@ type IGoQuant interface {
 	DecreaseFuncIndex(i int)
 	BarIndex() int
 	BarFuncIndex() int
+	GetCache(key string, index int) (float64, bool)
+	SetCache(key string, index int, value float64)
+	CallSite(skip int) string // key of the call made skip frames above the caller, see core.GoQuant.CallSite
 }
 
 type SerieWrapper struct {
@@ -0,0 +1,203 @@
+package serie
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Timeframe is N units of s (seconds), m (minutes), h (hours), D (days), W (weeks) or M (months)
+type Timeframe struct {
+	N    int
+	Unit byte
+}
+
+var intradayUnits = map[byte]float64{'s': 1, 'm': 60, 'h': 60 * 60}
+
+// ParseTimeframe parses "30s", "5m", "4h", "1D", "1W" and "1M"
+func ParseTimeframe(timeframe string) (Timeframe, error) {
+	if len(timeframe) > 1 {
+		unit := timeframe[len(timeframe)-1]
+		n, err := strconv.Atoi(timeframe[:len(timeframe)-1])
+
+		_, intraday := intradayUnits[unit]
+		if err == nil && n > 0 && (intraday || unit == 'D' || unit == 'W' || unit == 'M') {
+			return Timeframe{N: n, Unit: unit}, nil
+		}
+	}
+
+	return Timeframe{}, fmt.Errorf("invalid timeframe %q", timeframe)
+}
+
+func (tf Timeframe) String() string {
+	return fmt.Sprintf("%d%c", tf.N, tf.Unit)
+}
+
+// ResampleConfig sets the calendar buckets are aligned to, a nil config means UTC days from midnight
+type ResampleConfig struct {
+	Location *time.Location // default UTC
+
+	// SessionStart and SessionEnd are offsets from local midnight, bars outside the session are dropped.
+	// Days, weeks and months start at SessionStart; SessionEnd 0 means a 24h session,
+	// SessionEnd before SessionStart an overnight one (e.g. 17h to 16h)
+	SessionStart time.Duration
+	SessionEnd   time.Duration
+
+	WeekStartsOnSunday bool // weeks start on Monday by default
+}
+
+func (c *ResampleConfig) location() *time.Location {
+	if c == nil || c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+func (c *ResampleConfig) sessionStart() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.SessionStart
+}
+
+// sessionLength returns the length of the session, 24h for none
+func (c *ResampleConfig) sessionLength() time.Duration {
+	if c == nil || c.SessionEnd == 0 {
+		return 24 * time.Hour
+	}
+
+	length := (c.SessionEnd - c.SessionStart) % (24 * time.Hour)
+	if length <= 0 {
+		length += 24 * time.Hour
+	}
+	return length
+}
+
+// sessionDay returns the start of the trading day containing t
+func sessionDay(t time.Time, config *ResampleConfig) time.Time {
+	offset := int(config.sessionStart().Seconds())
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, offset, 0, t.Location())
+	if day.After(t) {
+		day = time.Date(t.Year(), t.Month(), t.Day()-1, 0, 0, offset, 0, t.Location())
+	}
+	return day
+}
+
+// InSession reports whether the timestamp t (seconds) falls inside the session of config
+func InSession(t float64, config *ResampleConfig) bool {
+	tm := time.Unix(int64(t), 0).In(config.location())
+	return tm.Sub(sessionDay(tm, config)) < config.sessionLength()
+}
+
+// epochDay counts the calendar days from 1970-01-01 to the date of t
+func epochDay(t time.Time) int {
+	return int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+}
+
+func floorDiv(a, b int) int {
+	return int(math.Floor(float64(a) / float64(b)))
+}
+
+// BucketStart returns the start of the timeframe bucket containing the timestamp t (seconds).
+// Intraday buckets are aligned to the session start, days, weeks and months are calendar aligned
+func BucketStart(t float64, tf Timeframe, config *ResampleConfig) float64 {
+	loc := config.location()
+	day := sessionDay(time.Unix(int64(t), 0).In(loc), config)
+	offset := int(config.sessionStart().Seconds())
+
+	if unit, intraday := intradayUnits[tf.Unit]; intraday {
+		size := float64(tf.N) * unit
+		start := float64(day.Unix())
+		return start + math.Floor((t-start)/size)*size
+	}
+
+	var date time.Time
+	switch tf.Unit {
+	case 'D':
+		days := epochDay(day)
+		date = time.Unix(int64(floorDiv(days, tf.N)*tf.N)*24*60*60, 0).UTC()
+	case 'W':
+		days := epochDay(day)
+		weekStart := time.Monday
+		if config != nil && config.WeekStartsOnSunday {
+			weekStart = time.Sunday
+		}
+		// first day starting a week since 1970-01-01, which was a Thursday
+		first := (int(weekStart) - int(time.Thursday) + 7) % 7
+		weeks := floorDiv(days-first, 7)
+		days = first + floorDiv(weeks, tf.N)*tf.N*7
+		date = time.Unix(int64(days)*24*60*60, 0).UTC()
+	case 'M':
+		months := day.Year()*12 + int(day.Month()) - 1
+		months = floorDiv(months, tf.N) * tf.N
+		date = time.Date(months/12, time.Month(months%12+1), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return float64(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, offset, 0, loc).Unix())
+}
+
+// BucketEnd returns the end of the bucket starting at start, which is the end of its last session
+func BucketEnd(start float64, tf Timeframe, config *ResampleConfig) float64 {
+	loc := config.location()
+	day := sessionDay(time.Unix(int64(start), 0).In(loc), config)
+	offset := int(config.sessionStart().Seconds())
+
+	if unit, intraday := intradayUnits[tf.Unit]; intraday {
+		sessionEnd := float64(day.Add(config.sessionLength()).Unix())
+		return math.Min(start+float64(tf.N)*unit, sessionEnd)
+	}
+
+	var next time.Time
+	switch tf.Unit {
+	case 'D':
+		next = time.Date(day.Year(), day.Month(), day.Day()+tf.N, 0, 0, offset, 0, loc)
+	case 'W':
+		next = time.Date(day.Year(), day.Month(), day.Day()+7*tf.N, 0, 0, offset, 0, loc)
+	default:
+		next = time.Date(day.Year(), day.Month()+time.Month(tf.N), day.Day(), 0, 0, offset, 0, loc)
+	}
+
+	lastDay := time.Date(next.Year(), next.Month(), next.Day()-1, 0, 0, offset, 0, loc)
+	return float64(lastDay.Add(config.sessionLength()).Unix())
+}
+
+// Resample aggregates bars into the timeframe: first open, highest high, lowest low, last close and summed volume.
+// Bars outside the session are dropped and gap bars (NaN) are skipped, so a bucket made only of gap bars
+// stays a NaN bar, like the ones added by fillBarGaps
+func Resample(bars []Bar, timeframe string, config *ResampleConfig) ([]Bar, error) {
+	tf, err := ParseTimeframe(timeframe)
+	if err != nil {
+		return nil, err
+	}
+
+	NaN := math.NaN()
+	var result []Bar
+	for _, bar := range bars {
+		if !InSession(bar.Time, config) {
+			continue
+		}
+
+		start := BucketStart(bar.Time, tf, config)
+		if len(result) == 0 || result[len(result)-1].Time != start {
+			result = append(result, Bar{Time: start, Open: NaN, High: NaN, Low: NaN, Close: NaN, Volume: NaN})
+		}
+
+		if NA(bar.Close) {
+			continue
+		}
+
+		last := &result[len(result)-1]
+		if NA(last.Close) {
+			last.Open, last.High, last.Low, last.Close, last.Volume = bar.Open, bar.High, bar.Low, bar.Close, NZ(bar.Volume)
+			continue
+		}
+
+		last.High = math.Max(last.High, bar.High)
+		last.Low = math.Min(last.Low, bar.Low)
+		last.Close = bar.Close
+		last.Volume += NZ(bar.Volume)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,173 @@
+package serie
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func timeframe(t *testing.T, value string) Timeframe {
+	t.Helper()
+	tf, err := ParseTimeframe(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tf
+}
+
+func TestBucketBoundaries(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	regular := &ResampleConfig{SessionStart: 9*time.Hour + 30*time.Minute, SessionEnd: 16 * time.Hour}
+	overnight := &ResampleConfig{SessionStart: 17 * time.Hour, SessionEnd: 16 * time.Hour}
+
+	tests := []struct {
+		name      string
+		timeframe string
+		config    *ResampleConfig
+		time      string
+		start     string
+		end       string
+		loc       *time.Location // of start and end, UTC if nil
+	}{
+		{"minutes", "15m", nil, "2024-01-01 10:07", "2024-01-01 10:00", "2024-01-01 10:15", nil},
+		{"hours", "4h", nil, "2024-01-01 05:30", "2024-01-01 04:00", "2024-01-01 08:00", nil},
+		{"hours from the session start", "1h", regular, "2024-01-01 10:45", "2024-01-01 10:30", "2024-01-01 11:30", nil},
+		{"last hour cut at the session end", "1h", regular, "2024-01-01 15:45", "2024-01-01 15:30", "2024-01-01 16:00", nil},
+		{"day", "1D", nil, "2024-01-02 23:59", "2024-01-02 00:00", "2024-01-03 00:00", nil},
+		{"day ends with the session", "1D", regular, "2024-01-02 12:00", "2024-01-02 09:30", "2024-01-02 16:00", nil},
+		{"overnight session before midnight", "1D", overnight, "2024-01-02 18:00", "2024-01-02 17:00", "2024-01-03 16:00", nil},
+		{"overnight session after midnight", "1D", overnight, "2024-01-02 03:00", "2024-01-01 17:00", "2024-01-02 16:00", nil},
+		{"day in the location", "1D", &ResampleConfig{Location: est}, "2024-01-02 03:00", "2024-01-01 00:00", "2024-01-02 00:00", est},
+		{"week from Monday", "1W", nil, "2024-01-03 12:00", "2024-01-01 00:00", "2024-01-08 00:00", nil},
+		{"Sunday in the week from Monday", "1W", nil, "2024-01-07 12:00", "2024-01-01 00:00", "2024-01-08 00:00", nil},
+		{"week from Sunday", "1W", &ResampleConfig{WeekStartsOnSunday: true}, "2024-01-03 12:00", "2023-12-31 00:00", "2024-01-07 00:00", nil},
+		{"month", "1M", nil, "2024-02-29 23:00", "2024-02-01 00:00", "2024-03-01 00:00", nil},
+		{"quarter", "3M", nil, "2024-05-10 00:00", "2024-04-01 00:00", "2024-07-01 00:00", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tf := timeframe(t, test.timeframe)
+			start := BucketStart(at(t, test.time, nil), tf, test.config)
+			if want := at(t, test.start, test.loc); start != want {
+				t.Errorf("BucketStart = %v, want %v", time.Unix(int64(start), 0).UTC(), time.Unix(int64(want), 0).UTC())
+			}
+
+			end := BucketEnd(start, tf, test.config)
+			if want := at(t, test.end, test.loc); end != want {
+				t.Errorf("BucketEnd = %v, want %v", time.Unix(int64(end), 0).UTC(), time.Unix(int64(want), 0).UTC())
+			}
+		})
+	}
+}
+
+func TestInSession(t *testing.T) {
+	regular := &ResampleConfig{SessionStart: 9*time.Hour + 30*time.Minute, SessionEnd: 16 * time.Hour}
+	overnight := &ResampleConfig{SessionStart: 17 * time.Hour, SessionEnd: 16 * time.Hour}
+
+	tests := []struct {
+		name   string
+		config *ResampleConfig
+		time   string
+		want   bool
+	}{
+		{"no session", nil, "2024-01-02 03:00", true},
+		{"before the open", regular, "2024-01-02 09:29", false},
+		{"at the open", regular, "2024-01-02 09:30", true},
+		{"before the close", regular, "2024-01-02 15:59", true},
+		{"at the close", regular, "2024-01-02 16:00", false},
+		{"overnight break", overnight, "2024-01-02 16:30", false},
+		{"overnight open", overnight, "2024-01-02 17:00", true},
+		{"overnight after midnight", overnight, "2024-01-02 03:00", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := InSession(at(t, test.time, nil), test.config); got != test.want {
+				t.Errorf("InSession = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestResample(t *testing.T) {
+	NaN := math.NaN()
+
+	// one minute bars from 09:58 to 10:11, their prices count the minutes from 10:00
+	var bars []Bar
+	for i := -2; i < 12; i++ {
+		v := float64(i)
+		bars = append(bars, Bar{Time: at(t, "2024-01-02 10:00", nil) + v*60, Open: v, High: v + 1, Low: v - 1, Close: v + 0.5, Volume: 1})
+	}
+	gaps := func(bars []Bar, minutes ...int) []Bar {
+		bars = append([]Bar{}, bars...)
+		for _, i := range minutes {
+			bars[i+2] = Bar{Time: bars[i+2].Time, Open: NaN, High: NaN, Low: NaN, Close: NaN, Volume: NaN}
+		}
+		return bars
+	}
+
+	tests := []struct {
+		name      string
+		bars      []Bar
+		timeframe string
+		config    *ResampleConfig
+		want      []Bar
+	}{
+		{
+			"five minutes",
+			bars,
+			"5m",
+			nil,
+			[]Bar{
+				{Time: at(t, "2024-01-02 09:55", nil), Open: -2, High: 0, Low: -3, Close: -0.5, Volume: 2},
+				{Time: at(t, "2024-01-02 10:00", nil), Open: 0, High: 5, Low: -1, Close: 4.5, Volume: 5},
+				{Time: at(t, "2024-01-02 10:05", nil), Open: 5, High: 10, Low: 4, Close: 9.5, Volume: 5},
+				{Time: at(t, "2024-01-02 10:10", nil), Open: 10, High: 12, Low: 9, Close: 11.5, Volume: 2},
+			},
+		},
+		{
+			"gap bars skipped, a bucket of gap bars stays a gap",
+			gaps(bars, 2, 5, 6, 7, 8, 9),
+			"5m",
+			&ResampleConfig{SessionStart: 10 * time.Hour, SessionEnd: 10*time.Hour + 10*time.Minute},
+			[]Bar{
+				{Time: at(t, "2024-01-02 10:00", nil), Open: 0, High: 5, Low: -1, Close: 4.5, Volume: 4},
+				{Time: at(t, "2024-01-02 10:05", nil), Open: NaN, High: NaN, Low: NaN, Close: NaN, Volume: NaN},
+			},
+		},
+		{
+			"day",
+			bars,
+			"1D",
+			nil,
+			[]Bar{{Time: at(t, "2024-01-02 00:00", nil), Open: -2, High: 12, Low: -3, Close: 11.5, Volume: 14}},
+		},
+	}
+
+	same := func(a, b float64) bool { return a == b || (NA(a) && NA(b)) }
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Resample(test.bars, test.timeframe, test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("%d bars, want %d: %+v", len(got), len(test.want), got)
+			}
+			for i, want := range test.want {
+				bar := got[i]
+				if !same(bar.Time, want.Time) || !same(bar.Open, want.Open) || !same(bar.High, want.High) ||
+					!same(bar.Low, want.Low) || !same(bar.Close, want.Close) || !same(bar.Volume, want.Volume) {
+					t.Errorf("bar %d = %+v, want %+v", i, bar, want)
+				}
+			}
+		})
+	}
+
+	if _, err := Resample(bars, "5x", nil); err == nil {
+		t.Error("Resample with timeframe 5x: no error")
+	}
+}
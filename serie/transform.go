@@ -0,0 +1,260 @@
+package serie
+
+import (
+	"math"
+)
+
+// BarTransform builds alternative bars from all the real bars, and returns for each of them the index of the real bar
+// it was completed on. Only completed bars are returned, so the result for a given prefix of the real bars
+// doesn't change when bars are added.
+// Transformed bars take the time of the real bar they were completed on, one second after the previous
+// transformed bar when several are completed on the same real bar, so their times always increase.
+// The transforms of this package keep their state between calls, so each call only reads the bars added since
+type BarTransform func(bars []Bar) (transformed []Bar, sources []int)
+
+type RenkoConfig struct {
+	BoxSize   float64 // size of the bricks
+	ATRLength int     // over 0, the size of a brick is the ATR(ATRLength) of the real bars when it's built instead
+}
+
+type PointAndFigureConfig struct {
+	BoxSize  float64
+	Reversal int // boxes a column needs to move back to start a new column, 3 by default
+}
+
+// transformed collects the bars of a BarTransform
+type transformed struct {
+	bars    []Bar
+	sources []int
+	volume  float64 // of the real bars since the last transformed bar
+}
+
+func (t *transformed) add(source int, time, open, close, high, low float64) {
+	if n := len(t.bars); n > 0 && time <= t.bars[n-1].Time {
+		time = t.bars[n-1].Time + 1
+	}
+
+	t.bars = append(t.bars, Bar{Time: time, Open: open, Close: close, High: high, Low: low, Volume: t.volume})
+	t.sources = append(t.sources, source)
+	t.volume = 0
+}
+
+// incremental makes a BarTransform of a step called on each real bar in order. It keeps the state between calls
+// and only steps through the bars added since the last one, so adding bars one by one stays linear. It starts over
+// from a new state when it's called with other bars, e.g. by another GoQuant
+func incremental(newStep func() func(t *transformed, i int, bar Bar)) BarTransform {
+	var t *transformed
+	var step func(t *transformed, i int, bar Bar)
+	var n int               // real bars stepped through
+	var first, last float64 // times of the first and last of them, to tell when other bars are given
+
+	return func(bars []Bar) ([]Bar, []int) {
+		if t == nil || n > len(bars) || (n > 0 && (bars[0].Time != first || bars[n-1].Time != last)) {
+			t, step, n = &transformed{}, newStep(), 0
+		}
+
+		for i := n; i < len(bars); i++ {
+			step(t, i, bars[i])
+		}
+		n = len(bars)
+		if n > 0 {
+			first, last = bars[0].Time, bars[n-1].Time
+		}
+
+		// capped, so appending to the results doesn't write over the next ones
+		return t.bars[:len(t.bars):len(t.bars)], t.sources[:len(t.sources):len(t.sources)]
+	}
+}
+
+// HeikinAshi averages each bar with the last one, one Heikin-Ashi bar per real bar
+func HeikinAshi() BarTransform {
+	return incremental(func() func(t *transformed, i int, bar Bar) {
+		return func(t *transformed, i int, bar Bar) {
+			ha := Bar{Time: bar.Time, Volume: bar.Volume}
+			ha.Close = (bar.Open + bar.High + bar.Low + bar.Close) / 4
+
+			// starts over after gap bars
+			if n := len(t.bars); n == 0 || NA(t.bars[n-1].Close) {
+				ha.Open = (bar.Open + bar.Close) / 2
+			} else {
+				ha.Open = (t.bars[n-1].Open + t.bars[n-1].Close) / 2
+			}
+
+			ha.High = math.Max(bar.High, math.Max(ha.Open, ha.Close))
+			ha.Low = math.Min(bar.Low, math.Min(ha.Open, ha.Close))
+			t.bars, t.sources = append(t.bars, ha), append(t.sources, i)
+		}
+	})
+}
+
+// wilderATR is Wilder's average true range of the bars passed to next, NaN until length bars that aren't gaps
+type wilderATR struct {
+	length    int
+	value     float64
+	count     int
+	prevClose float64
+}
+
+func newWilderATR(length int) *wilderATR {
+	return &wilderATR{length: length, prevClose: math.NaN()}
+}
+
+func (a *wilderATR) next(bar Bar) float64 {
+	if NA(bar.Close) {
+		return math.NaN()
+	}
+
+	tr := bar.High - bar.Low
+	if !NA(a.prevClose) {
+		tr = math.Max(tr, math.Max(math.Abs(bar.High-a.prevClose), math.Abs(bar.Low-a.prevClose)))
+	}
+	a.prevClose = bar.Close
+
+	a.count++
+	if a.count <= a.length {
+		a.value += tr / float64(a.length)
+	} else {
+		a.value = (a.value*float64(a.length-1) + tr) / float64(a.length)
+	}
+
+	if a.count < a.length {
+		return math.NaN()
+	}
+	return a.value
+}
+
+// Renko adds a brick each time the close moves a box beyond the last brick, two boxes to turn.
+// The volume of the real bars goes to the next brick
+func Renko(config RenkoConfig) BarTransform {
+	return incremental(func() func(t *transformed, i int, bar Bar) {
+		var atr *wilderATR
+		if config.ATRLength > 0 {
+			atr = newWilderATR(config.ATRLength)
+		}
+		top, bottom := math.NaN(), math.NaN()
+
+		return func(t *transformed, i int, bar Bar) {
+			box := config.BoxSize
+			if atr != nil {
+				box = atr.next(bar)
+			}
+			if NA(bar.Close) {
+				return
+			}
+			t.volume += NZ(bar.Volume)
+			if NA(box) || box <= 0 {
+				return
+			}
+
+			if NA(top) {
+				top, bottom = bar.Close, bar.Close
+				return
+			}
+
+			for bar.Close >= top+box {
+				t.add(i, bar.Time, top, top+box, top+box, top)
+				top, bottom = top+box, top
+			}
+			for bar.Close <= bottom-box {
+				t.add(i, bar.Time, bottom, bottom-box, bottom, bottom-box)
+				top, bottom = bottom, bottom-box
+			}
+		}
+	})
+}
+
+// LineBreak adds a line in the direction of the last one each time the close goes beyond it, and a line
+// the other way when the close goes beyond all the last lines (usually 3)
+func LineBreak(lines int) BarTransform {
+	return incremental(func() func(t *transformed, i int, bar Bar) {
+		base := math.NaN()
+
+		return func(t *transformed, i int, bar Bar) {
+			if NA(bar.Close) {
+				return
+			}
+			t.volume += NZ(bar.Volume)
+
+			if NA(base) {
+				base = bar.Close
+				return
+			}
+
+			n := len(t.bars)
+			if n == 0 {
+				if bar.Close != base {
+					t.add(i, bar.Time, base, bar.Close, math.Max(base, bar.Close), math.Min(base, bar.Close))
+				}
+				return
+			}
+
+			// range of the last lines
+			high, low := math.Inf(-1), math.Inf(1)
+			from := n - lines
+			if from < 0 {
+				from = 0
+			}
+			for _, line := range t.bars[from:] {
+				high, low = math.Max(high, line.High), math.Min(low, line.Low)
+			}
+
+			last := t.bars[n-1]
+			isUp := last.Close > last.Open
+			open := math.NaN()
+			switch {
+			case isUp && bar.Close > last.Close, !isUp && bar.Close < last.Close:
+				open = last.Close
+			case isUp && bar.Close < low, !isUp && bar.Close > high:
+				open = last.Open
+			}
+
+			if !NA(open) {
+				t.add(i, bar.Time, open, bar.Close, math.Max(open, bar.Close), math.Min(open, bar.Close))
+			}
+		}
+	})
+}
+
+// PointAndFigure adds a bar for each column of Xs (rising, Open is the bottom) or Os (falling, Open is the top)
+// on the closes, once the price reversed enough to start the next column
+func PointAndFigure(config PointAndFigureConfig) BarTransform {
+	box := config.BoxSize
+	reversal := float64(config.Reversal)
+	if reversal == 0 {
+		reversal = 3
+	}
+
+	return incremental(func() func(t *transformed, i int, bar Bar) {
+		// boxes of the current column, direction is 1 for Xs, -1 for Os, 0 until the first box
+		base, top, bottom, direction := math.NaN(), 0.0, 0.0, 0.0
+
+		return func(t *transformed, i int, bar Bar) {
+			if box <= 0 || NA(bar.Close) {
+				return
+			}
+			t.volume += NZ(bar.Volume)
+
+			up := math.Floor(bar.Close/box) * box  // highest box reached
+			down := math.Ceil(bar.Close/box) * box // lowest box reached
+
+			switch {
+			case NA(base):
+				base = up
+			case direction == 0 && bar.Close >= base+box:
+				direction, bottom, top = 1, base, up
+			case direction == 0 && bar.Close <= base-box:
+				direction, top, bottom = -1, base, down
+			case direction == 1 && bar.Close >= top+box:
+				top = up
+			case direction == 1 && bar.Close <= top-reversal*box:
+				t.add(i, bar.Time, bottom, top, top, bottom)
+				direction, top, bottom = -1, top-box, down
+			case direction == -1 && bar.Close <= bottom-box:
+				bottom = down
+			case direction == -1 && bar.Close >= bottom+reversal*box:
+				t.add(i, bar.Time, top, bottom, top, bottom)
+				direction, bottom, top = 1, bottom+box, up
+			}
+		}
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -126,12 +128,14 @@ func toFloat(value interface{}) (float64, error) {
 	}
 }
 
-// NormalizeTimestamps converts timestamps to seconds if they are in ms or ns
+// NormalizeTimestamps converts timestamps to seconds if they are in ms, µs or ns
 func normalizeTimestamps(bars []Bar) []Bar {
 	for i := range bars {
 		switch {
-		case bars[i].Time > 1e12: // Nanoseconds to seconds
+		case bars[i].Time > 1e16: // Nanoseconds to seconds
 			bars[i].Time = bars[i].Time / 1e9
+		case bars[i].Time > 1e13: // Microseconds to seconds
+			bars[i].Time = bars[i].Time / 1e6
 		case bars[i].Time > 1e10: // Milliseconds to seconds
 			bars[i].Time = bars[i].Time / 1e3
 		}
@@ -204,10 +208,15 @@ func ProcessBarsFromJsonString(jsonStr string) ([]Bar, error) {
 		bars = append(bars, bar)
 	}
 
-	// Step 3: Normalize timestamps
+	return prepareBars(bars)
+}
+
+// prepareBars normalizes timestamps, puts bars in chronological order and fills missing bars with NaN bars
+func prepareBars(bars []Bar) ([]Bar, error) {
+	// Step 1: Normalize timestamps
 	bars = normalizeTimestamps(bars)
 
-	// Step 4: Check if data needs to be reversed based on the first two bars' timestamps
+	// Step 2: Check if data needs to be reversed based on the first two bars' timestamps
 	if len(bars) >= 2 && bars[0].Time > bars[1].Time {
 		// Reverse the array
 		for i, j := 0, len(bars)-1; i < j; i, j = i+1, j-1 {
@@ -215,7 +224,7 @@ func ProcessBarsFromJsonString(jsonStr string) ([]Bar, error) {
 		}
 	}
 
-	// Step 5: Fill missing Bars
+	// Step 3: Fill missing Bars
 	bars, err := fillBarGaps(bars)
 	if err != nil {
 		return nil, fmt.Errorf("error filling bar gaps: %v", err)
@@ -224,7 +233,12 @@ func ProcessBarsFromJsonString(jsonStr string) ([]Bar, error) {
 	return bars, nil
 }
 
+// ProcessBarsFromPath reads a JSON array of bars, or a CSV file with the default CSVConfig when path ends with .csv
 func ProcessBarsFromPath(path string) ([]Bar, error) {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ProcessBarsFromCSVPath(path, nil)
+	}
+
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("error reading file %s: %v", path, err)
@@ -0,0 +1,399 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// pathPoint is a price on the assumed intrabar path, pos 0 is the open and len(path)-1 the close
+type pathPoint struct {
+	pos   float64
+	price float64
+}
+
+// pricePath assumes the bar visits the extreme closest to its open first: O-H-L-C or O-L-H-C
+func pricePath(bar serie.Bar) []float64 {
+	if bar.High-bar.Open < bar.Open-bar.Low {
+		return []float64{bar.Open, bar.High, bar.Low, bar.Close}
+	}
+	return []float64{bar.Open, bar.Low, bar.High, bar.Close}
+}
+
+// subPath returns the points of path starting at position from
+func subPath(path []float64, from float64) []pathPoint {
+	i := int(from)
+	if i >= len(path)-1 {
+		return []pathPoint{{pos: float64(len(path) - 1), price: path[len(path)-1]}}
+	}
+
+	frac := from - float64(i)
+	points := []pathPoint{{pos: from, price: path[i] + (path[i+1]-path[i])*frac}}
+	for j := i + 1; j < len(path); j++ {
+		points = append(points, pathPoint{pos: float64(j), price: path[j]})
+	}
+	return points
+}
+
+// reach returns the first point where sign*price >= sign*level, a level already passed fills at the current price
+func reach(points []pathPoint, level float64, sign float64) (float64, float64, bool) {
+	for j, p := range points {
+		if sign*p.price < sign*level {
+			continue
+		}
+
+		if j == 0 {
+			return p.pos, p.price, true
+		}
+
+		a := points[j-1]
+		return a.pos + (p.pos-a.pos)*(level-a.price)/(p.price-a.price), level, true
+	}
+
+	return 0, 0, false
+}
+
+// trail walks points with a trailing stop that follows the best price for a position closed by side,
+// it returns the fill if the stop is hit and the trailing state reached otherwise
+func (o *Order) trail(points []pathPoint, side float64) (pos, price float64, ok, active bool, extreme float64) {
+	t := -side // +1 trails below the highs, -1 above the lows
+	active, extreme = o.active, o.extreme
+
+	offset := func() float64 {
+		if o.TrailPercent > 0 {
+			return math.Abs(extreme) * o.TrailPercent / 100
+		}
+		return o.TrailPoints
+	}
+
+	for j, p := range points {
+		if !active {
+			if o.TrailActivation > 0 && t*p.price < t*o.TrailActivation {
+				continue
+			}
+
+			active = true
+			extreme = p.price
+			if o.TrailActivation > 0 && j > 0 {
+				extreme = o.TrailActivation
+			}
+		}
+
+		if math.IsNaN(extreme) || t*p.price > t*extreme {
+			extreme = p.price
+			continue
+		}
+
+		stop := extreme - t*offset()
+		if t*p.price > t*stop {
+			continue
+		}
+
+		if j == 0 || t*points[j-1].price <= t*stop {
+			return p.pos, p.price, true, active, extreme
+		}
+
+		a := points[j-1]
+		return a.pos + (p.pos-a.pos)*(stop-a.price)/(p.price-a.price), stop, true, active, extreme
+	}
+
+	return 0, 0, false, active, extreme
+}
+
+// side returns +1 when the order buys and -1 when it sells, false if it cannot fill now
+func (s *Strategy) side(o *Order) (float64, bool) {
+	if o.Entry {
+		return float64(o.Direction), true
+	}
+
+	for _, trade := range s.openTrades {
+		if o.FromEntry == "" || trade.EntryID == o.FromEntry {
+			return -float64(trade.Direction), true
+		}
+	}
+
+	return 0, false
+}
+
+// entryPrice returns the average price of the open trades an exit refers to
+func (s *Strategy) entryPrice(o *Order) float64 {
+	qty, value := 0.0, 0.0
+	for _, trade := range s.openTrades {
+		if o.FromEntry == "" || trade.EntryID == o.FromEntry {
+			qty += trade.Qty
+			value += trade.Qty * trade.EntryPrice
+		}
+	}
+	return value / qty
+}
+
+func (s *Strategy) levels(o *Order, side float64) (limit, stop float64) {
+	limit, stop = o.Limit, o.Stop
+	if o.Profit > 0 {
+		limit = s.entryPrice(o) - side*o.Profit
+	}
+	if o.Loss > 0 {
+		stop = s.entryPrice(o) + side*o.Loss
+	}
+	return limit, stop
+}
+
+// trigger finds where on path the order fills
+func (s *Strategy) trigger(o *Order, path []float64, side float64) (float64, float64, bool) {
+	points := subPath(path, o.since)
+	limit, stop := s.levels(o, side)
+
+	switch o.Type {
+	case Market:
+		return points[0].pos, points[0].price, true
+	case Limit:
+		return reach(points, limit, -side)
+	case Stop:
+		return reach(points, stop, side)
+	case StopLimit:
+		if !o.triggered {
+			pos, _, ok := reach(points, stop, side)
+			if !ok {
+				return 0, 0, false
+			}
+			points = subPath(path, pos)
+		}
+		return reach(points, limit, -side)
+	case TrailingStop:
+		pos, price, ok, _, _ := o.trail(points, side)
+		return pos, price, ok
+	}
+
+	return 0, 0, false
+}
+
+// advance keeps the progress of an unfilled order up to position to
+func (s *Strategy) advance(o *Order, path []float64, side float64, to float64) {
+	points := subPath(path, o.since)
+	for len(points) > 1 && points[len(points)-1].pos > to {
+		points = points[:len(points)-1]
+	}
+	if last := points[len(points)-1]; last.pos < to {
+		points = append(points, subPath(path, to)[0])
+	}
+
+	switch o.Type {
+	case StopLimit:
+		_, stop := s.levels(o, side)
+		if _, _, ok := reach(points, stop, side); ok {
+			o.triggered = true
+		}
+	case TrailingStop:
+		_, _, _, o.active, o.extreme = o.trail(points, side)
+	}
+	o.since = to
+}
+
+// ProcessBar fills the pending orders against the OHLC of bar and records the state at index.
+// It is called by GoQuant before the strategy logic of each bar runs
+func (s *Strategy) ProcessBar(index int, bar serie.Bar) {
+	if !serie.NA(bar.Open) && !serie.NA(bar.High) && !serie.NA(bar.Low) && !serie.NA(bar.Close) {
+		path := pricePath(bar)
+		end := float64(len(path) - 1)
+
+		for _, o := range s.orders {
+			o.since = 0
+		}
+
+		for {
+			var best *Order
+			var bestPos, bestPrice float64
+			for _, o := range s.orders {
+				side, ok := s.side(o)
+				if !ok {
+					continue
+				}
+
+				pos, price, ok := s.trigger(o, path, side)
+				if ok && (best == nil || pos < bestPos) {
+					best, bestPos, bestPrice = o, pos, price
+				}
+			}
+
+			if best == nil {
+				break
+			}
+
+			sides := make(map[*Order]float64)
+			for _, o := range s.orders {
+				sides[o], _ = s.side(o)
+			}
+
+			s.fill(best, bestPrice, index, bar.Time)
+
+			// exits see the new position from the fill on
+			for _, o := range s.orders {
+				side, ok := s.side(o)
+				if ok && side == sides[o] {
+					s.advance(o, path, side, bestPos)
+					continue
+				}
+
+				o.active, o.extreme, o.triggered = false, math.NaN(), false
+				o.since = bestPos
+			}
+		}
+
+		for _, o := range s.orders {
+			if side, ok := s.side(o); ok {
+				s.advance(o, path, side, end)
+			}
+		}
+	}
+
+	s.dropOrphanExits()
+	s.record(index, bar)
+}
+
+// dropOrphanExits cancels the exits whose trades were closed
+func (s *Strategy) dropOrphanExits() {
+	orders := s.orders[:0]
+	for _, o := range s.orders {
+		_, open := s.side(o)
+		if o.Entry || !o.armed || open {
+			o.armed = o.armed || open
+			orders = append(orders, o)
+		}
+	}
+	s.orders = orders
+}
+
+func (s *Strategy) remove(o *Order) {
+	orders := s.orders[:0]
+	for _, order := range s.orders {
+		if order != o {
+			orders = append(orders, order)
+		}
+	}
+	s.orders = orders
+}
+
+func (s *Strategy) commission(qty, price float64) float64 {
+	return qty*price*s.config.CommissionPercent/100 + s.config.CommissionPerOrder
+}
+
+func (s *Strategy) defaultQty(price float64) float64 {
+	switch s.config.QtyType {
+	case CashQty:
+		return s.config.QtyValue / price
+	case PercentOfEquity:
+		equity := s.cash + s.size()*price
+		return equity * s.config.QtyValue / 100 / price
+	}
+	return s.config.QtyValue
+}
+
+func (s *Strategy) fill(o *Order, price float64, index int, time float64) {
+	side, _ := s.side(o)
+	if o.Type == Market || o.Type == Stop || o.Type == TrailingStop {
+		price += side * s.config.Slippage
+	}
+
+	s.remove(o)
+
+	if !o.Entry {
+		s.closeTrades(o.FromEntry, o.Qty, o.ID, price, index, time)
+		s.Cancel(o.oca)
+		return
+	}
+
+	size := s.size()
+	if size*side < 0 {
+		s.closeTrades("", 0, o.ID, price, index, time)
+	} else {
+		entries := 0
+		for _, trade := range s.openTrades {
+			if trade.Direction == o.Direction {
+				entries++
+			}
+		}
+		if entries >= s.config.Pyramiding {
+			return
+		}
+	}
+
+	qty := o.Qty
+	if qty == 0 {
+		qty = s.defaultQty(price)
+	}
+
+	commission := s.commission(qty, price)
+	s.cash -= side*qty*price + commission
+	s.openTrades = append(s.openTrades, &Trade{
+		EntryID:    o.ID,
+		Direction:  o.Direction,
+		Qty:        qty,
+		EntryPrice: price,
+		EntryBar:   index,
+		EntryTime:  time,
+		Commission: commission,
+	})
+}
+
+// closeTrades closes up to qty (0 for all) of the open trades of fromEntry (empty for all), oldest first
+func (s *Strategy) closeTrades(fromEntry string, qty float64, exitID string, price float64, index int, time float64) {
+	total := 0.0
+	for _, trade := range s.openTrades {
+		if fromEntry == "" || trade.EntryID == fromEntry {
+			total += trade.Qty
+		}
+	}
+	if qty == 0 || qty > total {
+		qty = total
+	}
+	if qty == 0 {
+		return
+	}
+
+	exitCommission := s.commission(qty, price)
+	remaining := qty
+	openTrades := s.openTrades[:0]
+	for _, trade := range s.openTrades {
+		if remaining <= 0 || (fromEntry != "" && trade.EntryID != fromEntry) {
+			openTrades = append(openTrades, trade)
+			continue
+		}
+
+		closed := math.Min(remaining, trade.Qty)
+		remaining -= closed
+
+		entryCommission := trade.Commission * closed / trade.Qty
+		commission := entryCommission + exitCommission*closed/qty
+		profit := float64(trade.Direction)*closed*(price-trade.EntryPrice) - commission
+
+		s.cash += float64(trade.Direction)*closed*price - exitCommission*closed/qty
+		// 0 when the trade cost nothing
+		profitPercent := 0.0
+		if cost := closed * trade.EntryPrice; cost != 0 {
+			profitPercent = profit / cost * 100
+		}
+
+		s.trades = append(s.trades, Trade{
+			EntryID:       trade.EntryID,
+			ExitID:        exitID,
+			Direction:     trade.Direction,
+			Qty:           closed,
+			EntryPrice:    trade.EntryPrice,
+			EntryBar:      trade.EntryBar,
+			EntryTime:     trade.EntryTime,
+			ExitPrice:     price,
+			ExitBar:       index,
+			ExitTime:      time,
+			Commission:    commission,
+			Profit:        profit,
+			ProfitPercent: profitPercent,
+		})
+
+		if closed < trade.Qty {
+			trade.Commission -= entryCommission
+			trade.Qty -= closed
+			openTrades = append(openTrades, trade)
+		}
+	}
+	s.openTrades = openTrades
+}
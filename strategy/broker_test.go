@@ -0,0 +1,272 @@
+package strategy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// testGoQuant only tells the strategy the bar index its orders are placed on
+type testGoQuant struct {
+	index int
+}
+
+func (g *testGoQuant) IncreaseFuncIndex(i int)                        {}
+func (g *testGoQuant) DecreaseFuncIndex(i int)                        {}
+func (g *testGoQuant) BarIndex() int                                  { return g.index }
+func (g *testGoQuant) BarFuncIndex() int                              { return 0 }
+func (g *testGoQuant) GetCache(key string, index int) (float64, bool) { return 0, false }
+func (g *testGoQuant) SetCache(key string, index int, value float64)  {}
+func (g *testGoQuant) CallSite(skip int) string                       { return "" }
+
+// bar is a daily bar with the given prices
+func bar(index int, open, high, low, close float64) serie.Bar {
+	return serie.Bar{Time: float64(1700006400 + index*86400), Open: open, High: high, Low: low, Close: close}
+}
+
+// run processes the bars like GoQuant: the orders are filled against a bar, then logic runs on it
+func run(config *Config, bars []serie.Bar, logic func(s *Strategy, i int)) *Strategy {
+	g := &testGoQuant{}
+	s := New(g, config)
+	for i, b := range bars {
+		g.index = i
+		s.ProcessBar(i, b)
+		logic(s, i)
+	}
+
+	return s
+}
+
+type fill struct {
+	price float64
+	bar   int
+}
+
+func TestEntryFills(t *testing.T) {
+	tests := []struct {
+		name   string
+		config OrderConfig
+		next   serie.Bar
+		want   *fill // nil when the order doesn't fill
+	}{
+		{"market at the next open", OrderConfig{}, bar(1, 100, 110, 90, 105), &fill{100, 1}},
+		{"stop reached", OrderConfig{Stop: 105}, bar(1, 100, 107, 99, 106), &fill{105, 1}},
+		{"stop not reached", OrderConfig{Stop: 105}, bar(1, 100, 104, 99, 103), nil},
+		{"gap through the stop fills at the open", OrderConfig{Stop: 105}, bar(1, 110, 112, 108, 111), &fill{110, 1}},
+		{"limit reached", OrderConfig{Limit: 95}, bar(1, 100, 101, 94, 96), &fill{95, 1}},
+		{"gap through the limit fills at the open", OrderConfig{Limit: 95}, bar(1, 90, 92, 88, 91), &fill{90, 1}},
+		// O-L-H-C: triggered on the way up to the high, filled on the way down to the close
+		{"stop-limit triggered then filled", OrderConfig{Stop: 105, Limit: 103}, bar(1, 100, 106, 99.5, 99.8), &fill{103, 1}},
+		{"stop-limit triggered, limit not reached", OrderConfig{Stop: 105, Limit: 103}, bar(1, 100, 106, 102, 104), nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bars := []serie.Bar{bar(0, 100, 100, 100, 100), test.next}
+			s := run(nil, bars, func(s *Strategy, i int) {
+				if i == 0 {
+					config := test.config
+					s.Entry("long", Long, &config)
+				}
+			})
+
+			trades := s.OpenTrades()
+			if test.want == nil {
+				if len(trades) != 0 {
+					t.Fatalf("filled at %v, want no fill", trades[0].EntryPrice)
+				}
+				return
+			}
+			if len(trades) != 1 {
+				t.Fatalf("%d open trades, want 1", len(trades))
+			}
+			if trades[0].EntryPrice != test.want.price || trades[0].EntryBar != test.want.bar {
+				t.Errorf("filled at %v on bar %d, want %v on bar %d",
+					trades[0].EntryPrice, trades[0].EntryBar, test.want.price, test.want.bar)
+			}
+		})
+	}
+}
+
+func TestExitBracketFollowsThePricePath(t *testing.T) {
+	tests := []struct {
+		name string
+		bar  serie.Bar
+		want float64
+		exit OrderType
+	}{
+		// the high is closer to the open, the path is O-H-L-C
+		{"high first", bar(2, 100, 106, 93, 100), 105, Limit},
+		// the low is closer to the open, the path is O-L-H-C
+		{"low first", bar(2, 100, 107, 94, 100), 95, Stop},
+		{"gap below the stop", bar(2, 90, 92, 88, 91), 90, Stop},
+		{"gap above the limit", bar(2, 108, 109, 107, 108), 108, Limit},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bars := []serie.Bar{bar(0, 100, 100, 100, 100), bar(1, 100, 100, 100, 100), test.bar}
+			s := run(nil, bars, func(s *Strategy, i int) {
+				switch i {
+				case 0:
+					s.Entry("long", Long, nil)
+				case 1:
+					s.Exit("bracket", "long", &ExitConfig{Profit: 5, Loss: 5})
+				}
+			})
+
+			trades := s.Trades()
+			if len(trades) != 1 {
+				t.Fatalf("%d closed trades, want 1", len(trades))
+			}
+			if trades[0].ExitPrice != test.want || trades[0].ExitBar != 2 {
+				t.Errorf("exited at %v on bar %d, want %v (%s) on bar 2", trades[0].ExitPrice, trades[0].ExitBar, test.want, test.exit)
+			}
+			if orders := s.Orders(); len(orders) != 0 {
+				t.Errorf("%d orders left, the other exit of the bracket must be canceled", len(orders))
+			}
+		})
+	}
+}
+
+func TestTrailingStop(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ExitConfig
+		bars   []serie.Bar
+		want   *fill
+	}{
+		{
+			"follows the high",
+			ExitConfig{TrailPoints: 5},
+			// O-L-H-C: the high of 110 moves the stop to 105, hit on the way down the next bar
+			[]serie.Bar{bar(2, 100, 110, 99, 108), bar(3, 107, 108, 100, 101)},
+			&fill{105, 3},
+		},
+		{
+			"percent of the high",
+			ExitConfig{TrailPercent: 10},
+			[]serie.Bar{bar(2, 100, 120, 99, 115), bar(3, 115, 116, 100, 101)},
+			&fill{108, 3},
+		},
+		{
+			"waits for the activation price",
+			ExitConfig{TrailPoints: 5, TrailActivation: 120},
+			[]serie.Bar{bar(2, 100, 110, 90, 95), bar(3, 95, 96, 85, 90)},
+			nil,
+		},
+		{
+			"activated",
+			ExitConfig{TrailPoints: 5, TrailActivation: 104},
+			[]serie.Bar{bar(2, 100, 106, 99.5, 100)},
+			&fill{101, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bars := append([]serie.Bar{bar(0, 100, 100, 100, 100), bar(1, 100, 100, 100, 100)}, test.bars...)
+			s := run(nil, bars, func(s *Strategy, i int) {
+				switch i {
+				case 0:
+					s.Entry("long", Long, nil)
+				case 1:
+					config := test.config
+					s.Exit("trail", "long", &config)
+				}
+			})
+
+			trades := s.Trades()
+			if test.want == nil {
+				if len(trades) != 0 {
+					t.Fatalf("exited at %v, want no exit", trades[0].ExitPrice)
+				}
+				return
+			}
+			if len(trades) != 1 {
+				t.Fatalf("%d closed trades, want 1", len(trades))
+			}
+			if math.Abs(trades[0].ExitPrice-test.want.price) > 1e-9 || trades[0].ExitBar != test.want.bar {
+				t.Errorf("exited at %v on bar %d, want %v on bar %d",
+					trades[0].ExitPrice, trades[0].ExitBar, test.want.price, test.want.bar)
+			}
+		})
+	}
+}
+
+func TestPyramidingAndReversal(t *testing.T) {
+	flat := func(i int) serie.Bar { return bar(i, 100, 100, 100, 100) }
+	bars := []serie.Bar{flat(0), flat(1), flat(2), flat(3), flat(4)}
+
+	tests := []struct {
+		name       string
+		pyramiding int
+		want       int
+	}{
+		{"one entry by default", 0, 1},
+		{"up to pyramiding entries", 2, 2},
+		{"no more than the entries placed", 10, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := run(&Config{Pyramiding: test.pyramiding}, bars, func(s *Strategy, i int) {
+				s.Entry("long", Long, nil)
+			})
+
+			if trades := s.OpenTrades(); len(trades) != test.want {
+				t.Errorf("%d open trades, want %d", len(trades), test.want)
+			}
+		})
+	}
+
+	t.Run("reversal", func(t *testing.T) {
+		bars := []serie.Bar{flat(0), bar(1, 100, 100, 100, 100), bar(2, 110, 110, 110, 110), flat(3)}
+		s := run(&Config{Pyramiding: 3, QtyValue: 2}, bars, func(s *Strategy, i int) {
+			switch i {
+			case 0:
+				s.Entry("long", Long, nil)
+			case 1:
+				s.Entry("short", Short, nil)
+			}
+		})
+
+		trades, open := s.Trades(), s.OpenTrades()
+		if len(trades) != 1 || trades[0].EntryID != "long" || trades[0].ExitPrice != 110 || trades[0].Profit != 20 {
+			t.Errorf("closed trades %+v, want the long closed at 110 for a profit of 20", trades)
+		}
+		if len(open) != 1 || open[0].Direction != Short || open[0].EntryPrice != 110 || open[0].Qty != 2 {
+			t.Errorf("open trades %+v, want a short of 2 at 110", open)
+		}
+	})
+}
+
+func TestSlippageAndCommission(t *testing.T) {
+	bars := []serie.Bar{bar(0, 100, 100, 100, 100), bar(1, 100, 100, 100, 100), bar(2, 120, 120, 120, 120)}
+	config := &Config{Slippage: 1, CommissionPercent: 1, CommissionPerOrder: 2, QtyValue: 10}
+	s := run(config, bars, func(s *Strategy, i int) {
+		switch i {
+		case 0:
+			s.Entry("long", Long, nil)
+		case 1:
+			s.Close("long")
+		}
+	})
+
+	trades := s.Trades()
+	if len(trades) != 1 {
+		t.Fatalf("%d closed trades, want 1", len(trades))
+	}
+
+	// bought at 101 and sold at 119, 1% of 1010 and 1190 plus 2 per order
+	trade := trades[0]
+	wantCommission := 10.1 + 2 + 11.9 + 2
+	if trade.EntryPrice != 101 || trade.ExitPrice != 119 || math.Abs(trade.Commission-wantCommission) > 1e-9 {
+		t.Errorf("entry %v, exit %v, commission %v, want 101, 119 and %v",
+			trade.EntryPrice, trade.ExitPrice, trade.Commission, wantCommission)
+	}
+	if want := 10*18 - wantCommission; math.Abs(trade.Profit-want) > 1e-9 {
+		t.Errorf("profit %v, want %v", trade.Profit, want)
+	}
+}
@@ -0,0 +1,221 @@
+package strategy
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+const secondsPerYear = 365.25 * 24 * 60 * 60
+
+type EquityPoint struct {
+	Index    int      `json:"index"`
+	Time     int      `json:"timestamp"`
+	Equity   *float64 `json:"equity"`
+	Drawdown *float64 `json:"drawdown"` // percent below the running peak
+}
+
+// Report summarizes the performance of a strategy, ratios that can't be computed are 0
+type Report struct {
+	InitialCapital     float64 `json:"initialCapital"`
+	FinalEquity        float64 `json:"finalEquity"`
+	NetProfit          float64 `json:"netProfit"`
+	NetProfitPercent   float64 `json:"netProfitPercent"`
+	GrossProfit        float64 `json:"grossProfit"`
+	GrossLoss          float64 `json:"grossLoss"`
+	OpenProfit         float64 `json:"openProfit"`
+	Commission         float64 `json:"commission"`
+	CAGR               float64 `json:"cagr"` // percent
+	MaxDrawdown        float64 `json:"maxDrawdown"`
+	MaxDrawdownPercent float64 `json:"maxDrawdownPercent"`
+	MaxDrawdownBars    int     `json:"maxDrawdownBars"` // longest stretch of bars below a previous equity peak
+
+	// ratios are annualized from per bar returns
+	Sharpe  float64 `json:"sharpe"`
+	Sortino float64 `json:"sortino"`
+	Calmar  float64 `json:"calmar"`
+
+	TotalTrades     int     `json:"totalTrades"`
+	WinningTrades   int     `json:"winningTrades"`
+	LosingTrades    int     `json:"losingTrades"`
+	EvenTrades      int     `json:"evenTrades"` // closed with a profit of exactly 0, neither winning nor losing
+	WinRate         float64 `json:"winRate"`    // percent of all the trades
+	ProfitFactor    float64 `json:"profitFactor"`
+	Expectancy      float64 `json:"expectancy"`
+	AvgTrade        float64 `json:"avgTrade"`
+	AvgTradePercent float64 `json:"avgTradePercent"`
+	AvgWin          float64 `json:"avgWin"`
+	AvgLoss         float64 `json:"avgLoss"`
+	AvgBarsInTrade  float64 `json:"avgBarsInTrade"`
+
+	EquityCurve []EquityPoint `json:"equityCurve"`
+	Trades      []Trade       `json:"trades"`
+}
+
+// Report computes the performance of the bars processed so far
+func (s *Strategy) Report() Report {
+	r := Report{
+		InitialCapital: s.config.InitialCapital,
+		FinalEquity:    s.config.InitialCapital,
+		Trades:         append([]Trade{}, s.trades...),
+		EquityCurve:    []EquityPoint{},
+	}
+
+	s.reportTrades(&r)
+	s.reportEquity(&r)
+
+	if r.InitialCapital > 0 {
+		r.NetProfitPercent = r.NetProfit / r.InitialCapital * 100
+	}
+	if r.MaxDrawdownPercent > 0 {
+		r.Calmar = r.CAGR / r.MaxDrawdownPercent
+	}
+
+	return r
+}
+
+func (s *Strategy) reportTrades(r *Report) {
+	bars := 0
+	percent := 0.0
+	for _, trade := range s.trades {
+		r.NetProfit += trade.Profit
+		r.Commission += trade.Commission
+		percent += trade.ProfitPercent
+		bars += trade.ExitBar - trade.EntryBar
+
+		switch {
+		case trade.Profit > 0:
+			r.WinningTrades++
+			r.GrossProfit += trade.Profit
+		case trade.Profit < 0:
+			r.LosingTrades++
+			r.GrossLoss += -trade.Profit
+		default:
+			r.EvenTrades++
+		}
+	}
+
+	for _, trade := range s.openTrades {
+		r.Commission += trade.Commission
+		if !serie.NA(s.lastPrice) {
+			r.OpenProfit += float64(trade.Direction)*trade.Qty*(s.lastPrice-trade.EntryPrice) - trade.Commission
+		}
+	}
+
+	r.TotalTrades = len(s.trades)
+	if r.TotalTrades == 0 {
+		return
+	}
+
+	total := float64(r.TotalTrades)
+	r.WinRate = float64(r.WinningTrades) / total * 100
+	r.AvgTrade = r.NetProfit / total
+	r.AvgTradePercent = percent / total
+	r.AvgBarsInTrade = float64(bars) / total
+
+	if r.WinningTrades > 0 {
+		r.AvgWin = r.GrossProfit / float64(r.WinningTrades)
+	}
+	if r.LosingTrades > 0 {
+		r.AvgLoss = -r.GrossLoss / float64(r.LosingTrades)
+	}
+	if r.GrossLoss > 0 {
+		r.ProfitFactor = r.GrossProfit / r.GrossLoss
+	}
+
+	// even trades add nothing
+	r.Expectancy = (float64(r.WinningTrades)*r.AvgWin + float64(r.LosingTrades)*r.AvgLoss) / total
+}
+
+func (s *Strategy) reportEquity(r *Report) {
+	peak := math.NaN()
+	drawdownStart := -1
+	var returns, intervals []float64
+	first, last := -1, -1
+
+	for i, equity := range s.equity {
+		point := EquityPoint{Index: i, Time: int(serie.NZ(s.times[i]))}
+		if serie.NA(equity) {
+			r.EquityCurve = append(r.EquityCurve, point)
+			continue
+		}
+
+		if last < 0 {
+			first = i
+		} else if s.equity[last] > 0 {
+			returns = append(returns, equity/s.equity[last]-1)
+			intervals = append(intervals, (s.times[i]-s.times[last])/float64(i-last))
+		}
+		last = i
+
+		if serie.NA(peak) || equity >= peak {
+			peak = equity
+			drawdownStart = i
+		}
+
+		// no percent below a peak at or under 0, e.g. with an initial capital of 0
+		drawdown := peak - equity
+		drawdownPercent := 0.0
+		if peak > 0 {
+			drawdownPercent = drawdown / peak * 100
+		}
+		if drawdown > r.MaxDrawdown {
+			r.MaxDrawdown = drawdown
+		}
+		if drawdownPercent > r.MaxDrawdownPercent {
+			r.MaxDrawdownPercent = drawdownPercent
+		}
+		if i-drawdownStart > r.MaxDrawdownBars {
+			r.MaxDrawdownBars = i - drawdownStart
+		}
+
+		e, d := equity, -drawdownPercent
+		point.Equity, point.Drawdown = &e, &d
+		r.EquityCurve = append(r.EquityCurve, point)
+	}
+
+	if last < 0 {
+		return
+	}
+	r.FinalEquity = s.equity[last]
+
+	years := (s.times[last] - s.times[first]) / secondsPerYear
+	if years > 0 && r.FinalEquity > 0 && r.InitialCapital > 0 {
+		r.CAGR = (math.Pow(r.FinalEquity/r.InitialCapital, 1/years) - 1) * 100
+	}
+
+	if len(returns) < 2 {
+		return
+	}
+
+	// bars per year from the typical bar interval, robust to gap bars and weekends
+	sort.Float64s(intervals)
+	interval := intervals[len(intervals)/2]
+	if interval <= 0 {
+		return
+	}
+	periods := secondsPerYear / interval
+	riskFree := s.config.RiskFreeRate / 100 / periods
+
+	mean, downside := 0.0, 0.0
+	for _, ret := range returns {
+		mean += ret - riskFree
+		downside += math.Pow(math.Min(0, ret-riskFree), 2)
+	}
+	mean /= float64(len(returns))
+	downside = math.Sqrt(downside / float64(len(returns)))
+
+	variance := 0.0
+	for _, ret := range returns {
+		variance += math.Pow(ret-riskFree-mean, 2)
+	}
+	stdev := math.Sqrt(variance / float64(len(returns)-1))
+
+	if stdev > 0 {
+		r.Sharpe = mean / stdev * math.Sqrt(periods)
+	}
+	if downside > 0 {
+		r.Sortino = mean / downside * math.Sqrt(periods)
+	}
+}
@@ -0,0 +1,127 @@
+package strategy
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+// metrics returns the numbers of r that don't depend on the time of the bars
+func metrics(r Report) map[string]float64 {
+	return map[string]float64{
+		"InitialCapital":     r.InitialCapital,
+		"FinalEquity":        r.FinalEquity,
+		"NetProfit":          r.NetProfit,
+		"NetProfitPercent":   r.NetProfitPercent,
+		"GrossProfit":        r.GrossProfit,
+		"GrossLoss":          r.GrossLoss,
+		"OpenProfit":         r.OpenProfit,
+		"MaxDrawdown":        r.MaxDrawdown,
+		"MaxDrawdownPercent": r.MaxDrawdownPercent,
+		"MaxDrawdownBars":    float64(r.MaxDrawdownBars),
+		"TotalTrades":        float64(r.TotalTrades),
+		"WinningTrades":      float64(r.WinningTrades),
+		"LosingTrades":       float64(r.LosingTrades),
+		"EvenTrades":         float64(r.EvenTrades),
+		"WinRate":            r.WinRate,
+		"ProfitFactor":       r.ProfitFactor,
+		"Expectancy":         r.Expectancy,
+		"AvgTrade":           r.AvgTrade,
+		"AvgWin":             r.AvgWin,
+		"AvgLoss":            r.AvgLoss,
+		"AvgBarsInTrade":     r.AvgBarsInTrade,
+	}
+}
+
+func TestReport(t *testing.T) {
+	flat := func(i int, price float64) serie.Bar { return bar(i, price, price, price, price) }
+
+	// a long of 1 entered and closed on every other bar, filled at the next open
+	roundTrips := func(s *Strategy, i int) {
+		if i%2 == 0 {
+			s.Entry("long", Long, nil)
+		} else {
+			s.Close("long")
+		}
+	}
+
+	tests := []struct {
+		name  string
+		bars  []serie.Bar
+		logic func(s *Strategy, i int)
+		want  Report
+	}{
+		{
+			"no bars",
+			nil,
+			roundTrips,
+			Report{InitialCapital: 10000, FinalEquity: 10000},
+		},
+		{
+			"win, even and loss",
+			[]serie.Bar{flat(0, 100), flat(1, 100), flat(2, 110), flat(3, 110), flat(4, 110), flat(5, 110), flat(6, 105)},
+			roundTrips,
+			Report{
+				InitialCapital:     10000,
+				FinalEquity:        10005,
+				NetProfit:          5,
+				NetProfitPercent:   0.05,
+				GrossProfit:        10,
+				GrossLoss:          5,
+				MaxDrawdown:        5,
+				MaxDrawdownPercent: 5.0 / 10010 * 100,
+				MaxDrawdownBars:    1,
+				TotalTrades:        3,
+				WinningTrades:      1,
+				LosingTrades:       1,
+				EvenTrades:         1,
+				WinRate:            100.0 / 3,
+				ProfitFactor:       2,
+				Expectancy:         5.0 / 3,
+				AvgTrade:           5.0 / 3,
+				AvgWin:             10,
+				AvgLoss:            -5,
+				AvgBarsInTrade:     1,
+			},
+		},
+		{
+			"open trade",
+			[]serie.Bar{flat(0, 100), flat(1, 100), flat(2, 90)},
+			func(s *Strategy, i int) {
+				if i == 0 {
+					s.Entry("long", Long, nil)
+				}
+			},
+			Report{
+				InitialCapital:     10000,
+				FinalEquity:        9990,
+				OpenProfit:         -10,
+				MaxDrawdown:        10,
+				MaxDrawdownPercent: 0.1,
+				MaxDrawdownBars:    1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := run(nil, test.bars, test.logic).Report()
+
+			if len(r.EquityCurve) != len(test.bars) {
+				t.Errorf("%d equity points, want %d", len(r.EquityCurve), len(test.bars))
+			}
+			if _, err := json.Marshal(r); err != nil {
+				t.Errorf("json.Marshal: %v", err)
+			}
+
+			got, want := metrics(r), metrics(test.want)
+			for name, w := range want {
+				if math.Abs(got[name]-w) > 1e-9 {
+					t.Errorf("%s = %v, want %v", name, got[name], w)
+				}
+			}
+		})
+	}
+}
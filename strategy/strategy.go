@@ -0,0 +1,382 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/Go-Quant/goquant/serie"
+)
+
+type Direction int
+
+const (
+	Long  Direction = 1
+	Short Direction = -1
+)
+
+type OrderType string
+
+const (
+	Market       OrderType = "market"
+	Limit        OrderType = "limit"
+	Stop         OrderType = "stop"
+	StopLimit    OrderType = "stopLimit"
+	TrailingStop OrderType = "trailingStop"
+)
+
+type QtyType int
+
+const (
+	FixedQty        QtyType = iota // QtyValue units per order
+	CashQty                        // QtyValue worth of cash per order
+	PercentOfEquity                // QtyValue percent of the current equity per order
+)
+
+type Config struct {
+	InitialCapital     float64 // default 10000
+	QtyType            QtyType
+	QtyValue           float64 // default 1
+	CommissionPercent  float64 // percent of the traded value
+	CommissionPerOrder float64 // fixed cash per filled order
+	Slippage           float64 // price units, applied against market and stop fills
+	Pyramiding         int     // max open entries in the same direction, default 1
+	RiskFreeRate       float64 // annual percent, used by the Sharpe and Sortino ratios
+}
+
+// OrderConfig describes an entry; zero fields are unset.
+// Limit makes a limit order, Stop a stop order, both a stop-limit order and Trail* a trailing stop order,
+// otherwise the order is filled at the open of the next bar
+type OrderConfig struct {
+	Qty             float64
+	Limit           float64
+	Stop            float64
+	TrailPoints     float64 // trailing distance in price units
+	TrailPercent    float64 // trailing distance in percent of the best price
+	TrailActivation float64 // trailing starts once this price is reached
+}
+
+// ExitConfig describes a bracket of exit orders, the first one to fill cancels the others.
+// Profit and Loss are distances from the entry price, Limit and Stop absolute prices
+type ExitConfig struct {
+	Qty             float64 // 0 closes the whole entry
+	Limit           float64
+	Stop            float64
+	Profit          float64
+	Loss            float64
+	TrailPoints     float64
+	TrailPercent    float64
+	TrailActivation float64
+}
+
+type Order struct {
+	ID        string    `json:"id"`
+	Type      OrderType `json:"type"`
+	Direction Direction `json:"direction"` // entries only, exits follow the position they close
+	Entry     bool      `json:"entry"`
+	FromEntry string    `json:"fromEntry,omitempty"` // exits only, empty for the whole position
+	Qty       float64   `json:"qty"`
+	Limit     float64   `json:"limit,omitempty"`
+	Stop      float64   `json:"stop,omitempty"`
+	Profit    float64   `json:"profit,omitempty"`
+	Loss      float64   `json:"loss,omitempty"`
+	Bar       int       `json:"bar"` // bar index the order was placed on
+
+	TrailPoints     float64 `json:"trailPoints,omitempty"`
+	TrailPercent    float64 `json:"trailPercent,omitempty"`
+	TrailActivation float64 `json:"trailActivation,omitempty"`
+
+	oca       string  // exits of the same Exit call
+	triggered bool    // stop-limit: the stop was reached, it is a limit order now
+	armed     bool    // exits: the entry was open at least once
+	active    bool    // trailing: activation price was reached
+	extreme   float64 // trailing: best price since activation
+	since     float64 // position on the current bar's price path from which the order can fill
+}
+
+type Trade struct {
+	EntryID       string    `json:"entryId"`
+	ExitID        string    `json:"exitId,omitempty"`
+	Direction     Direction `json:"direction"`
+	Qty           float64   `json:"qty"`
+	EntryPrice    float64   `json:"entryPrice"`
+	EntryBar      int       `json:"entryBar"`
+	EntryTime     float64   `json:"entryTime"`
+	ExitPrice     float64   `json:"exitPrice,omitempty"`
+	ExitBar       int       `json:"exitBar,omitempty"`
+	ExitTime      float64   `json:"exitTime,omitempty"`
+	Commission    float64   `json:"commission"`
+	Profit        float64   `json:"profit"` // net of commission
+	ProfitPercent float64   `json:"profitPercent"`
+}
+
+type Strategy struct {
+	GoQuant serie.IGoQuant
+	config  Config
+
+	cash       float64
+	lastPrice  float64
+	orders     []*Order
+	openTrades []*Trade
+	trades     []Trade
+
+	positionSize []float64
+	avgPrice     []float64
+	equity       []float64
+	cashHistory  []float64
+	times        []float64
+}
+
+// New creates a strategy evaluated by g, nil config uses the defaults
+func New(g serie.IGoQuant, config *Config) *Strategy {
+	if config == nil {
+		config = &Config{}
+	}
+
+	c := *config
+	if c.InitialCapital == 0 {
+		c.InitialCapital = 10000
+	}
+	if c.QtyValue == 0 {
+		c.QtyValue = 1
+	}
+	if c.Pyramiding == 0 {
+		c.Pyramiding = 1
+	}
+
+	return &Strategy{GoQuant: g, config: c, cash: c.InitialCapital, lastPrice: math.NaN()}
+}
+
+// Reset drops the orders, trades and history, back to the initial capital
+func (s *Strategy) Reset() {
+	*s = Strategy{GoQuant: s.GoQuant, config: s.config, cash: s.config.InitialCapital, lastPrice: math.NaN()}
+}
+
+func (s *Strategy) Config() Config {
+	return s.config
+}
+
+// Entry places an entry order, filled from the next bar on. An entry in the opposite direction
+// reverses the position, an order with an existing pending id replaces it
+func (s *Strategy) Entry(id string, direction Direction, config *OrderConfig) {
+	if config == nil {
+		config = &OrderConfig{}
+	}
+
+	order := &Order{
+		ID:              id,
+		Direction:       direction,
+		Entry:           true,
+		Qty:             config.Qty,
+		Limit:           config.Limit,
+		Stop:            config.Stop,
+		TrailPoints:     config.TrailPoints,
+		TrailPercent:    config.TrailPercent,
+		TrailActivation: config.TrailActivation,
+		Bar:             s.GoQuant.BarIndex(),
+	}
+
+	switch {
+	case config.TrailPoints > 0 || config.TrailPercent > 0:
+		order.Type = TrailingStop
+	case config.Limit > 0 && config.Stop > 0:
+		order.Type = StopLimit
+	case config.Limit > 0:
+		order.Type = Limit
+	case config.Stop > 0:
+		order.Type = Stop
+	default:
+		order.Type = Market
+	}
+
+	s.place(id, order)
+}
+
+// Exit places a bracket of exit orders for the trades opened by fromEntry, empty fromEntry exits the whole position.
+// Calling it again with the same id replaces the pending bracket
+func (s *Strategy) Exit(id string, fromEntry string, config *ExitConfig) {
+	if config == nil {
+		config = &ExitConfig{}
+	}
+
+	var orders []*Order
+	exit := func(orderType OrderType) *Order {
+		return &Order{
+			ID:        id,
+			Type:      orderType,
+			FromEntry: fromEntry,
+			Qty:       config.Qty,
+			Bar:       s.GoQuant.BarIndex(),
+			oca:       id,
+		}
+	}
+
+	if config.Limit > 0 || config.Profit > 0 {
+		order := exit(Limit)
+		order.Limit = config.Limit
+		order.Profit = config.Profit
+		orders = append(orders, order)
+	}
+
+	if config.Stop > 0 || config.Loss > 0 {
+		order := exit(Stop)
+		order.Stop = config.Stop
+		order.Loss = config.Loss
+		orders = append(orders, order)
+	}
+
+	if config.TrailPoints > 0 || config.TrailPercent > 0 {
+		order := exit(TrailingStop)
+		order.TrailPoints = config.TrailPoints
+		order.TrailPercent = config.TrailPercent
+		order.TrailActivation = config.TrailActivation
+		orders = append(orders, order)
+	}
+
+	s.place(id, orders...)
+}
+
+// Close exits the trades opened by entryID at the open of the next bar, it does nothing without such trades
+func (s *Strategy) Close(entryID string) {
+	if _, open := s.side(&Order{FromEntry: entryID}); !open {
+		return
+	}
+
+	id := "close " + entryID
+	s.place(id, &Order{ID: id, Type: Market, FromEntry: entryID, Bar: s.GoQuant.BarIndex(), oca: id})
+}
+
+// CloseAll exits the whole position at the open of the next bar
+func (s *Strategy) CloseAll() {
+	s.Close("")
+}
+
+// place replaces the pending orders of id, a replaced order of the same type keeps
+// its progress (triggered stop, trailing extreme) so calling Entry/Exit on every bar is safe
+func (s *Strategy) place(id string, orders ...*Order) {
+	for _, order := range orders {
+		order.extreme = math.NaN()
+		for _, pending := range s.orders {
+			if pending.ID == id && pending.Type == order.Type && pending.Entry == order.Entry {
+				order.triggered = pending.triggered
+				order.armed = pending.armed
+				order.active = pending.active
+				order.extreme = pending.extreme
+			}
+		}
+	}
+
+	s.Cancel(id)
+	s.orders = append(s.orders, orders...)
+}
+
+// Cancel removes the pending orders with the given id
+func (s *Strategy) Cancel(id string) {
+	orders := s.orders[:0]
+	for _, order := range s.orders {
+		if order.ID != id && order.oca != id {
+			orders = append(orders, order)
+		}
+	}
+	s.orders = orders
+}
+
+func (s *Strategy) CancelAll() {
+	s.orders = nil
+}
+
+// Orders returns the pending orders
+func (s *Strategy) Orders() []Order {
+	orders := make([]Order, len(s.orders))
+	for i, order := range s.orders {
+		orders[i] = *order
+	}
+	return orders
+}
+
+// OpenTrades returns the trades of the current position
+func (s *Strategy) OpenTrades() []Trade {
+	trades := make([]Trade, len(s.openTrades))
+	for i, trade := range s.openTrades {
+		trades[i] = *trade
+	}
+	return trades
+}
+
+// Trades returns the closed trades
+func (s *Strategy) Trades() []Trade {
+	return s.trades
+}
+
+func (s *Strategy) PositionSize() serie.Serie {
+	return s.history(&s.positionSize)
+}
+
+func (s *Strategy) PositionAvgPrice() serie.Serie {
+	return s.history(&s.avgPrice)
+}
+
+func (s *Strategy) Equity() serie.Serie {
+	return s.history(&s.equity)
+}
+
+func (s *Strategy) Cash() serie.Serie {
+	return s.history(&s.cashHistory)
+}
+
+// history exposes per bar values recorded by ProcessBar as a serie
+func (s *Strategy) history(values *[]float64) serie.Serie {
+	return serie.NewWrapper(s.GoQuant, func() float64 {
+		index := s.GoQuant.BarIndex() - s.GoQuant.BarFuncIndex()
+		if index < 0 || index >= len(*values) {
+			return math.NaN()
+		}
+
+		return (*values)[index]
+	})
+}
+
+func (s *Strategy) size() float64 {
+	size := 0.0
+	for _, trade := range s.openTrades {
+		size += float64(trade.Direction) * trade.Qty
+	}
+	return size
+}
+
+func (s *Strategy) avg() float64 {
+	qty, value := 0.0, 0.0
+	for _, trade := range s.openTrades {
+		qty += trade.Qty
+		value += trade.Qty * trade.EntryPrice
+	}
+
+	if qty == 0 {
+		return math.NaN()
+	}
+	return value / qty
+}
+
+// record stores the state of the bar at index, marked to the bar close
+func (s *Strategy) record(index int, bar serie.Bar) {
+	if !serie.NA(bar.Close) {
+		s.lastPrice = bar.Close
+	}
+
+	size := s.size()
+	equity := s.cash
+	if size != 0 {
+		equity += size * s.lastPrice
+	}
+
+	values := []*[]float64{&s.positionSize, &s.avgPrice, &s.equity, &s.cashHistory, &s.times}
+	for _, v := range values {
+		for len(*v) <= index {
+			*v = append(*v, math.NaN())
+		}
+	}
+
+	s.positionSize[index] = size
+	s.avgPrice[index] = s.avg()
+	s.equity[index] = equity
+	s.cashHistory[index] = s.cash
+	s.times[index] = bar.Time
+}